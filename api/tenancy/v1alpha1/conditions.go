@@ -32,6 +32,12 @@ const (
 	RbacCreatedReason = "RbacCreated"
 	// RbacCreationFailedReason is the reason when RBAC resource creation fails.
 	RbacCreationFailedReason = "RbacCreationFailed"
+	// ReconciliationFailedReason is the reason when the workspace resources could not be reconciled.
+	ReconciliationFailedReason = "ReconciliationFailed"
 	// ResourcesCreatedReason is the reason when all resources are successfully created/updated.
 	ResourcesCreatedReason = "ResourcesCreated"
+	// WorkspaceSuspendedReason is the reason when the workspace and its Applications are suspended.
+	WorkspaceSuspendedReason = "Suspended"
+	// SuspensionFailedReason is the reason when the suspend state could not be propagated to the Applications.
+	SuspensionFailedReason = "SuspensionFailed"
 )
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+	"text/template"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +32,12 @@ const (
 const (
 	// WorkspaceLinkedResourceLabel is the label for the linked resource.
 	WorkspaceLinkedResourceLabel = "tenancy.fcp.funccloud.com/workspace"
+	// WorkspaceSuspendedLabel is set to "true" on the Applications of a suspended workspace. It is
+	// informational only: the Application controller reads the suspended state from the Workspace.
+	WorkspaceSuspendedLabel = "tenancy.fcp.funccloud.com/suspended"
+	// WorkspaceImagePullSecretsAnnotation lists the image pull secrets the workspace added to the
+	// default ServiceAccount, so that secrets removed from the workspace are removed from it too.
+	WorkspaceImagePullSecretsAnnotation = "tenancy.fcp.funccloud.com/image-pull-secrets"
 )
 
 type WorkspaceType string
@@ -50,6 +59,45 @@ type WorkspaceSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// kubebuilder:validation:Required
 	Owners []corev1.ObjectReference `json:"owners,omitempty"`
+	// Suspended scales every Application of the workspace to zero and blocks new ones.
+	Suspended bool `json:"suspended,omitempty"`
+	// ApplicationDomainTemplate is a Go template giving new Applications of the workspace a default
+	// domain when they declare none, e.g. "{{.Name}}.{{.Workspace}}.apps.example.com".
+	// {{.Name}} is the Application name and {{.Workspace}} the workspace name.
+	// +optional
+	ApplicationDomainTemplate string `json:"applicationDomainTemplate,omitempty"`
+	// ImagePullSecrets are Secrets of the workspace namespace holding private registry credentials.
+	// They are added to the default ServiceAccount of the workspace and to every Application.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// DefaultContainerResources are the resource requests and limits given to the containers of new
+	// Applications of the workspace that declare none, so CPU and memory based autoscaling has
+	// requests to work from instead of the Knative cluster defaults.
+	// +optional
+	DefaultContainerResources *corev1.ResourceRequirements `json:"defaultContainerResources,omitempty"`
+}
+
+// ApplicationDomain renders the ApplicationDomainTemplate for the named Application.
+// It returns an empty domain when the workspace has no template.
+func (w *Workspace) ApplicationDomain(application string) (string, error) {
+	if w.Spec.ApplicationDomainTemplate == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("domain").Option("missingkey=error").Parse(w.Spec.ApplicationDomainTemplate)
+	if err != nil {
+		return "", err
+	}
+	var domain strings.Builder
+	if err := tmpl.Execute(&domain, map[string]string{"Name": application, "Workspace": w.Name}); err != nil {
+		return "", err
+	}
+	return domain.String(), nil
+}
+
+// OwnerRoleBindingName returns the name of the RoleBinding granting the owners access to the
+// workspace namespace through the Role named after the workspace.
+func (w *Workspace) OwnerRoleBindingName() string {
+	return "fcp-ownership-" + w.Name
 }
 
 // WorkspaceStatus defines the observed state of Workspace.
@@ -63,6 +111,7 @@ type WorkspaceStatus struct {
 // +kubebuilder:resource:scope=Cluster,shortName="ws"
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="The type of the workspace"
 // +kubebuilder:printcolumn:name="Owners",type="string",JSONPath=".spec.owners[*].name",description="The owners of the workspace"
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspended",description="Whether the workspace is suspended"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description="The status of the workspace"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="The status of the workspace"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the workspace"
@@ -21,6 +21,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/serving/pkg/apis/autoscaling"
 )
 
@@ -29,6 +31,17 @@ const (
 	ApplicationFinalizer = "application.fcp.funccloud.com/finalizer"
 	// ApplicationLabel is the label for the Application
 	ApplicationLabel = "fcp.funccloud.com/application"
+	// AdoptAnnotation marks a pre-existing Knative Service as safe to be taken over by the Application
+	AdoptAnnotation = "fcp.funccloud.com/adopt"
+	// DefaultLivenessProbeAnnotation set to "true" opts the Application into a TCP liveness probe on
+	// the serving port of its serving container when that container has no liveness probe
+	DefaultLivenessProbeAnnotation = "fcp.funccloud.com/default-liveness-probe"
+	// ConfigRolloutLabel set to "true" on a ConfigMap or Secret lets changes to it roll out the
+	// Applications that reference it from their env and set spec.rolloutOnConfigChange
+	ConfigRolloutLabel = "fcp.funccloud.com/rollout-on-change"
+	// ConfigHashAnnotation records on the revision template the hash of the ConfigMaps and Secrets
+	// watched through ConfigRolloutLabel, so a change to them creates a new revision
+	ConfigHashAnnotation = "fcp.funccloud.com/config-hash"
 	// DefaultRolloutDuration is the default rollout duration for the Application
 	DefaultRolloutDuration = 5 * time.Minute
 	// DefaultEnableTLS is the default enable TLS for the Application
@@ -39,6 +52,17 @@ const (
 	DefaultMinReplicas = int32(0)
 	// DefaultMaxReplicas is the default maximum replicas for the Application
 	DefaultMaxReplicas = int32(1)
+	// TrustBundleKey is the ConfigMap key holding the PEM encoded CA bundle
+	TrustBundleKey = "ca.crt"
+	// TrustBundleMountPath is where the trust bundle is mounted in the application containers
+	TrustBundleMountPath = "/etc/fcp/trust-bundle"
+	// TrustBundleVolumeName is the name of the volume carrying the trust bundle
+	TrustBundleVolumeName = "fcp-trust-bundle"
+	// DefaultMetricsPath is the default HTTP path scraped for application metrics
+	DefaultMetricsPath = "/metrics"
+	// AppPortVariable is resolved to the port of the ingress container when referenced as
+	// $(FCP_APP_PORT) in a container environment variable value
+	AppPortVariable = "FCP_APP_PORT"
 )
 
 type Metric string
@@ -54,6 +78,19 @@ const (
 	MetricRPS Metric = "rps"
 )
 
+// Profile is a curated set of Knative autoscaling settings tuned for a kind of workload.
+// +kubebuilder:validation:Enum=low-latency;batch;cost-optimized
+type Profile string
+
+const (
+	// ProfileLowLatency reacts quickly to load and keeps pods warm longer before scaling down
+	ProfileLowLatency Profile = "low-latency"
+	// ProfileBatch runs few concurrent long requests per pod and ignores short load spikes
+	ProfileBatch Profile = "batch"
+	// ProfileCostOptimized packs requests densely and scales down as soon as load drops
+	ProfileCostOptimized Profile = "cost-optimized"
+)
+
 func (m Metric) GetClass() string {
 	switch m {
 	case MetricCPU, MetricMemory:
@@ -66,21 +103,120 @@ func (m Metric) GetClass() string {
 
 // ApplicationSpec defines the desired state of Application.
 type ApplicationSpec struct {
-	// Containers is the list of containers of the application
+	// Containers is the list of containers of the application.
+	// Environment variable values may reference $(FCP_APP_PORT), which is resolved to the
+	// port of the container serving the application traffic.
+	// A container declaring several ports must name exactly one of them http1 or h2c; that port
+	// receives the traffic and the probes, the others are only reachable inside the pod.
+	// Lifecycle hooks are not supported by Knative; use TerminationGracePeriodSeconds to let
+	// in-flight requests finish on shutdown instead of a preStop hook.
 	Containers []corev1.Container `json:"containers,omitempty"`
 	// +kubebuilder:validation:Required
 	// Scale is the scale of the application
 	Scale Scale `json:"scale,omitempty"`
 	// ImagePullSecrets is the image pull secrets of the application
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
-	// RolloutDuration is the rollout duration of the application
+	// RolloutDuration is the rollout duration of the application.
+	// Knative shifts traffic to a new revision gradually over this duration, in steps it computes
+	// itself; the step percentage is not configurable. Zero moves all traffic at once.
+	// It must be a whole number of seconds.
 	// +kubebuilder:validation:Required
 	RolloutDuration *metav1.Duration `json:"rolloutDuration,omitempty"`
 	// EnableTLS indicates whether to enable TLS for the application
 	// +kubebuilder:validation:Required
 	EnableTLS *bool `json:"enableTLS,omitempty"`
-	// Domains is the custom domains of the application
+	// TLSRedirect indicates whether plain HTTP requests are redirected to HTTPS when TLS is enabled.
+	// Defaults to true; set it to false to keep serving plain HTTP, e.g. for probers or ACME HTTP-01 challenges.
+	// +optional
+	TLSRedirect *bool `json:"tlsRedirect,omitempty"`
+	// Domains is the custom domains of the application.
+	// Each entry must be a bare host name, serving the application under a path is not supported.
+	// When unset on creation, it defaults to the domain rendered from the workspace
+	// ApplicationDomainTemplate, if any.
 	Domains []string `json:"domains,omitempty"`
+	// Hostname is a DNS label the application is also served under, below the platform domain the
+	// manager is configured with, e.g. "api" for api.example.com. It can be combined with Domains.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// TrustBundleConfigMap is the name of a ConfigMap in the workspace whose "ca.crt" key holds
+	// extra CA certificates to trust. It is mounted into every container and SSL_CERT_FILE points to it.
+	TrustBundleConfigMap string `json:"trustBundleConfigMap,omitempty"`
+	// Metrics configures Prometheus scraping of the application
+	Metrics *Metrics `json:"metrics,omitempty"`
+	// ExposePodMetadata injects POD_NAME, POD_NAMESPACE and POD_IP into every container
+	// through the downward API. Variables already set on a container take precedence.
+	ExposePodMetadata bool `json:"exposePodMetadata,omitempty"`
+	// RevisionNamePrefix names the Knative revisions "<application>-<prefix><generation>",
+	// e.g. "myapp-v3" for the prefix "v", instead of letting Knative pick a random suffix.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*)?$`
+	RevisionNamePrefix string `json:"revisionNamePrefix,omitempty"`
+	// RolloutOnConfigChange creates a new revision whenever a ConfigMap or Secret referenced from
+	// the env of the containers changes. Only ConfigMaps and Secrets labelled
+	// fcp.funccloud.com/rollout-on-change=true are watched.
+	RolloutOnConfigChange bool `json:"rolloutOnConfigChange,omitempty"`
+	// TopologySpreadConstraints spreads the application pods across topology domains such as zones.
+	// Constraints without a label selector select the pods of the application.
+	// Requires the Knative feature "kubernetes.podspec-topologyspreadconstraints".
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// DisruptionBudget creates a PodDisruptionBudget for the application pods, limiting how many
+	// of them voluntary disruptions such as node drains may evict at once.
+	// +optional
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+	// TerminationGracePeriodSeconds is how long a shutting down pod waits for in-flight requests
+	// to finish before it is killed. Knative applies it as the revision timeout, so it also bounds
+	// the duration of every request; it cannot exceed the Knative max-revision-timeout-seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// EnableServiceLinks indicates whether the environment variables describing the services of the
+	// workspace are injected into the containers. Unset keeps the Knative default, which disables them.
+	// +optional
+	EnableServiceLinks *bool `json:"enableServiceLinks,omitempty"`
+	// IngressClass selects the Knative ingress serving the application and its domains, e.g.
+	// "kourier.ingress.networking.knative.dev". Unset uses the cluster default ingress class.
+	// +optional
+	IngressClass string `json:"ingressClass,omitempty"`
+	// Profile applies a curated set of autoscaling and request buffering settings: low-latency,
+	// batch or cost-optimized. The Scale fields, when set, take precedence over the profile.
+	// +optional
+	Profile Profile `json:"profile,omitempty"`
+}
+
+// DisruptionBudget configures the PodDisruptionBudget of an application.
+// Exactly one of MinAvailable and MaxUnavailable must be set.
+type DisruptionBudget struct {
+	// MinAvailable is the number or percentage of application pods that must remain available
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of application pods that may be unavailable
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ServingPortNames are the port names Knative recognises as the serving port of a container,
+// for HTTP/1 and HTTP/2 cleartext traffic. A container declaring several ports must name exactly
+// one of them after a protocol so that it can be told apart.
+var ServingPortNames = sets.New("http1", "h2c")
+
+// PodMetadataEnv are the downward API environment variables injected by ExposePodMetadata.
+var PodMetadataEnv = []corev1.EnvVar{
+	{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+	{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+	{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+}
+
+// Metrics describes where the application exposes Prometheus metrics.
+type Metrics struct {
+	// Port is the container port serving the metrics endpoint
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Path is the HTTP path of the metrics endpoint, defaults to /metrics
+	Path string `json:"path,omitempty"`
+	// ServiceMonitor requests a Prometheus Operator ServiceMonitor for the application.
+	// It is only created when the ServiceMonitor CRD is installed in the cluster.
+	ServiceMonitor bool `json:"serviceMonitor,omitempty"`
 }
 
 type Scale struct {
@@ -92,6 +228,12 @@ type Scale struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Minimum=1
 	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// InitialScale is the number of replicas a new revision starts with before the autoscaler takes
+	// over, so a cold application can start with more than minReplicas. Defaults to minReplicas.
+	// Starting at 0 requires allow-zero-initial-scale in the Knative autoscaler config.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialScale *int32 `json:"initialScale,omitempty"`
 	// TargetUtilizationPercentage is the target  utilization percentage for the application
 	TargetUtilizationPercentage *int32 `json:"targetUtilizationPercentage,omitempty"`
 	// Target is the target of the application
@@ -105,6 +247,22 @@ type ApplicationStatus struct {
 	Status `json:",inline"` // Embed workload status
 	// URLs is the list of URLs of the application
 	URLs []string `json:"urls,omitempty"`
+	// LatestReadyRevision is the name of the latest Knative revision that became ready
+	LatestReadyRevision string `json:"latestReadyRevision,omitempty"`
+	// Traffic is the traffic distribution across revisions currently served by the Knative Route
+	Traffic []TrafficStatus `json:"traffic,omitempty"`
+}
+
+// TrafficStatus is the share of the application traffic routed to a revision.
+type TrafficStatus struct {
+	// RevisionName is the Knative revision receiving the traffic
+	RevisionName string `json:"revisionName"`
+	// Percent is the percentage of the traffic routed to the revision
+	Percent int64 `json:"percent"`
+	// Tag is the name of the tagged route of the revision, if any
+	Tag string `json:"tag,omitempty"`
+	// LatestRevision indicates the target follows the latest ready revision
+	LatestRevision bool `json:"latestRevision,omitempty"`
 }
 
 // +kubebuilder:object:root=true
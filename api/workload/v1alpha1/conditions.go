@@ -21,6 +21,8 @@ const (
 	KnativeServiceNotFoundReason          = "KnativeServiceNotFound"
 	KnativeServiceNotReadyReason          = "KnativeServiceNotReady"
 	KnativeServiceReadyReason             = "KnativeServiceReady"
+	KnativeServiceAdoptionRefusedReason   = "KnativeServiceAdoptionRefused"
+	KnativeServiceOwnedElsewhereReason    = "KnativeServiceOwnedElsewhere"
 
 	// --- DomainMappingReady Condition Reasons ---
 	DomainMappingCheckFailedReason    = "DomainMappingCheckFailed" // Added
@@ -28,5 +30,6 @@ const (
 	DomainMappingCreationFailedReason = "DomainMappingCreationFailed"
 	DomainMappingNotConfiguredReason  = "DomainMappingNotConfigured"
 	DomainMappingReadyReason          = "DomainMappingReady"
+	DomainMappingNotReadyReason       = "DomainMappingNotReady"
 	DomainMappingCleanupFailedReason  = "DomainMappingCleanupFailed" // Added
 )
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"go.funccloud.dev/fcp/internal/cmd"
 	"k8s.io/component-base/cli"
@@ -18,6 +21,14 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseDevMode(false)))
 	logs.GlogSetter(cmd.GetLogVerbosity(os.Args)) // nolint:errcheck
 	command := cmd.NewDefaultFCPCommand()
+	// Cancel the command context on the first interrupt so long running commands such as install
+	// stop their waits promptly; a second interrupt falls back to the default behaviour and exits.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	command.SetContext(ctx)
 	if err := cli.RunNoErrOutput(command); err != nil {
 		// Pretty-print the error and exit with an error.
 		util.CheckErr(err)
@@ -17,23 +17,34 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
 	tenancycontroller "go.funccloud.dev/fcp/internal/controller/tenancy"
 	workloadcontroller "go.funccloud.dev/fcp/internal/controller/workload"
+	"go.funccloud.dev/fcp/internal/health"
+	"go.funccloud.dev/fcp/internal/manager"
 	"go.funccloud.dev/fcp/internal/scheme"
 	webhooktenancyv1alpha1 "go.funccloud.dev/fcp/internal/webhook/tenancy/v1alpha1"
 	webhookworkloadv1alpha1 "go.funccloud.dev/fcp/internal/webhook/workload/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -44,6 +55,9 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// crdCheckInterval is how often the manager logs the CRDs that are still missing.
+const crdCheckInterval = 10 * time.Second
+
 func init() {
 	scheme.AddKnative()
 }
@@ -55,12 +69,39 @@ func main() {
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
 	var probeAddr string
+	var managerConfig manager.Config
+	var ingressClasses []string
+	var platformDomain string
+	var revisionLabelPrefixes []string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.DurationVar(&managerConfig.ResyncPeriod, "resync-period", manager.DefaultResyncPeriod,
+		fmt.Sprintf("How often Applications and Workspaces are fully reconciled even without changes. "+
+			"Must be between %s and %s.", manager.MinResyncPeriod, manager.MaxResyncPeriod))
+	flag.Func("watch-namespaces", "Comma separated namespaces to restrict the cache of Applications to, "+
+		"for sharded deployments. Workspaces are cluster-scoped and always watched. Defaults to all namespaces.",
+		func(value string) error {
+			managerConfig.WatchNamespaces = strings.Split(value, ",")
+			return nil
+		})
+	flag.Func("ingress-classes", "Comma separated Knative ingress classes Applications may select "+
+		"through spec.ingressClass. Defaults to accepting any class.",
+		func(value string) error {
+			ingressClasses = strings.Split(value, ",")
+			return nil
+		})
+	flag.StringVar(&platformDomain, "platform-domain", "",
+		"Domain Application hostnames (spec.hostname) are served under, e.g. apps.example.com.")
+	flag.Func("revision-label-prefixes", "Comma separated label key prefixes, e.g. team,cost-center. "+
+		"Application labels matching one of them are copied onto the Knative revisions. Defaults to none.",
+		func(value string) error {
+			revisionLabelPrefixes = strings.Split(value, ",")
+			return nil
+		})
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -174,7 +215,7 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(k8sConfig, ctrl.Options{
+	mgrOptions := ctrl.Options{
 		Scheme:                 scheme.Get(),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
@@ -192,7 +233,13 @@ func main() {
 		// if you are doing or is intended to do any operation such as perform cleanups
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
-	})
+	}
+	if err := managerConfig.Apply(&mgrOptions); err != nil {
+		setupLog.Error(err, "invalid manager options")
+		os.Exit(1)
+	}
+
+	mgr, err := ctrl.NewManager(k8sConfig, mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -213,15 +260,17 @@ func main() {
 		}
 	}
 	if err = (&workloadcontroller.ApplicationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		PlatformDomain:        platformDomain,
+		RevisionLabelPrefixes: revisionLabelPrefixes,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Application")
 		os.Exit(1)
 	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = webhookworkloadv1alpha1.SetupApplicationWebhookWithManager(mgr); err != nil {
+		if err = webhookworkloadv1alpha1.SetupApplicationWebhookWithManager(mgr, ingressClasses...); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Application")
 			os.Exit(1)
 		}
@@ -252,6 +301,29 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("cache-sync", health.CacheSynced(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up cache sync check")
+		os.Exit(1)
+	}
+	requiredKinds := []schema.GroupVersionKind{
+		tenancyv1alpha1.GroupVersion.WithKind("Workspace"),
+		workloadv1alpha1.GroupVersion.WithKind("Application"),
+		servingv1.SchemeGroupVersion.WithKind("Service"),
+	}
+	if err := mgr.AddReadyzCheck("crds", health.CRDsInstalled(mgr.GetRESTMapper(), requiredKinds...)); err != nil {
+		setupLog.Error(err, "unable to set up CRD check")
+		os.Exit(1)
+	}
+	// Report missing CRDs from a runnable rather than before starting the manager, so the probe
+	// endpoint is served meanwhile and the crds check keeps the pod unready instead of restarting it.
+	if err := mgr.Add(ctrlmanager.RunnableFunc(func(ctx context.Context) error {
+		// The wait only fails when the manager stops, which is not an error of the runnable.
+		_ = health.WaitForCRDs(ctrl.LoggerInto(ctx, setupLog), mgr.GetRESTMapper(), crdCheckInterval, requiredKinds...)
+		return nil
+	})); err != nil {
+		setupLog.Error(err, "unable to set up CRD wait")
+		os.Exit(1)
+	}
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
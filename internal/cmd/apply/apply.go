@@ -0,0 +1,258 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/scheme"
+	webhookworkloadv1alpha1 "go.funccloud.dev/fcp/internal/webhook/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/yamlutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	applyLong = templates.LongDesc(i18n.T(`
+		Apply manifests to the cluster using server-side apply.
+
+		Applications are defaulted client-side with the same rules as the admission
+		webhook before they are sent, including the defaults of their workspace.
+		With --dry-run=client the cluster is not contacted, so the workspace default
+		domain, resources and image pull secrets are left out of the output and only
+		added by the webhook. Objects without a namespace are applied to the
+		workspace of the current context.`))
+
+	applyExample = templates.Examples(i18n.T(`
+		# Apply an application manifest
+		fcp apply -f app.yaml
+
+		# Show the defaulted objects without applying them
+		fcp apply -f app.yaml --dry-run=client`))
+)
+
+// ErrInvalidApplication is returned when an Application fails client-side validation.
+var ErrInvalidApplication = errors.New("application is invalid")
+
+const (
+	dryRunNone   = "none"
+	dryRunClient = "client"
+	dryRunServer = "server"
+)
+
+type Options struct {
+	Filenames []string
+	DryRun    string
+	Namespace string
+	genericiooptions.IOStreams
+	Client client.Client
+}
+
+func NewCmdApply(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{
+		DryRun:    dryRunNone,
+		IOStreams: ioStreams,
+	}
+	cmd := &cobra.Command{
+		Use:     "apply -f FILENAME",
+		Short:   i18n.T("Apply manifests, defaulting Applications client-side"),
+		Long:    applyLong,
+		Example: applyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.Filenames, "filename", "f", o.Filenames, "Files containing the manifests to apply, use - for stdin")
+	cmd.Flags().StringVar(&o.DryRun, "dry-run", o.DryRun, `Must be "none", "server", or "client". With "client" the defaulted objects are printed without being sent.`)
+	return cmd
+}
+
+func (o *Options) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	if o.DryRun == dryRunClient {
+		return nil
+	}
+	cfg, err := f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.Client, err = client.New(cfg, client.Options{
+		Scheme: scheme.Get(),
+	})
+	return err
+}
+
+func (o *Options) Validate() error {
+	if len(o.Filenames) == 0 {
+		return fmt.Errorf("at least one filename must be specified with -f")
+	}
+	switch o.DryRun {
+	case dryRunNone, dryRunClient, dryRunServer:
+	default:
+		return fmt.Errorf("invalid --dry-run value %q, must be %q, %q or %q", o.DryRun, dryRunNone, dryRunServer, dryRunClient)
+	}
+	return nil
+}
+
+func (o *Options) Run(ctx context.Context) error {
+	var objs []*unstructured.Unstructured
+	for _, filename := range o.Filenames {
+		data, err := o.readFile(filename)
+		if err != nil {
+			return err
+		}
+		decoded, err := yamlutil.DecodeManifest(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", filename, err)
+		}
+		objs = append(objs, decoded...)
+	}
+
+	for i, obj := range objs {
+		isApplication := obj.GroupVersionKind().GroupKind() == workloadv1alpha1.GroupVersion.WithKind("Application").GroupKind()
+		if obj.GetNamespace() == "" {
+			namespaced := isApplication
+			if !namespaced && o.Client != nil {
+				var err error
+				if namespaced, err = o.Client.IsObjectNamespaced(obj); err != nil {
+					return fmt.Errorf("failed to resolve %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+				}
+			}
+			if namespaced {
+				obj.SetNamespace(o.Namespace)
+			}
+		}
+		if !isApplication {
+			continue
+		}
+		defaulted, err := o.defaultApplication(ctx, obj)
+		if err != nil {
+			return err
+		}
+		objs[i] = defaulted
+	}
+
+	if o.DryRun == dryRunClient {
+		return o.print(objs)
+	}
+	for _, obj := range objs {
+		if err := o.apply(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) readFile(filename string) ([]byte, error) {
+	if filename == "-" {
+		return io.ReadAll(o.In)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	return data, nil
+}
+
+// defaultApplication runs the webhook defaulting and offline validation on an Application
+// and returns it as an unstructured object ready to be applied. With a client, the workspace
+// defaults are applied too.
+func (o *Options) defaultApplication(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	app := &workloadv1alpha1.Application{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, app); err != nil {
+		return nil, fmt.Errorf("failed to decode application %q: %w", obj.GetName(), err)
+	}
+	defaulter := &webhookworkloadv1alpha1.ApplicationCustomDefaulter{}
+	if o.Client != nil {
+		defaulter.Client = o.Client
+		// The webhook only gives new Applications the workspace default domain and resources,
+		// so an existing Application keeps its creation timestamp while being defaulted.
+		existing := &workloadv1alpha1.Application{}
+		err := o.Client.Get(ctx, client.ObjectKeyFromObject(app), existing)
+		switch {
+		case err == nil:
+			app.CreationTimestamp = existing.CreationTimestamp
+		case !apierrors.IsNotFound(err):
+			return nil, fmt.Errorf("failed to get application %q: %w", app.Name, err)
+		}
+	}
+	if err := defaulter.Default(ctx, app); err != nil {
+		return nil, err
+	}
+	if errs := webhookworkloadv1alpha1.ValidateApplicationSpec(app); len(errs) > 0 {
+		_, _ = fmt.Fprintf(o.ErrOut, "application/%s in workspace %q is invalid:\n", app.Name, app.Namespace)
+		for _, e := range errs {
+			_, _ = fmt.Fprintf(o.ErrOut, "  - %s\n", e.Error())
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidApplication, app.Name)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(app)
+	if err != nil {
+		return nil, err
+	}
+	defaulted := &unstructured.Unstructured{Object: content}
+	defaulted.SetGroupVersionKind(obj.GroupVersionKind())
+	unstructured.RemoveNestedField(defaulted.Object, "status")
+	unstructured.RemoveNestedField(defaulted.Object, "metadata", "creationTimestamp")
+	return defaulted, nil
+}
+
+func (o *Options) print(objs []*unstructured.Unstructured) error {
+	for i, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			_, _ = fmt.Fprintln(o.Out, "---")
+		}
+		_, _ = fmt.Fprint(o.Out, string(data))
+	}
+	return nil
+}
+
+func (o *Options) apply(ctx context.Context, obj *unstructured.Unstructured) error {
+	opts := []client.PatchOption{client.ForceOwnership, client.FieldOwner("fcp")}
+	if o.DryRun == dryRunServer {
+		opts = append(opts, client.DryRunAll)
+	}
+	kind := obj.GroupVersionKind().Kind
+	if err := o.Client.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		return friendlyError(obj, err)
+	}
+	suffix := ""
+	if o.DryRun == dryRunServer {
+		suffix = " (server dry run)"
+	}
+	_, _ = fmt.Fprintf(o.Out, "%s/%s applied%s\n", kind, obj.GetName(), suffix)
+	return nil
+}
+
+// friendlyError rewrites common apply failures in terms of fcp concepts.
+func friendlyError(obj *unstructured.Unstructured, err error) error {
+	var status apierrors.APIStatus
+	if apierrors.IsNotFound(err) && errors.As(err, &status) &&
+		status.Status().Details != nil && status.Status().Details.Kind == "namespaces" {
+		return fmt.Errorf("workspace %q does not exist, create it before applying %s/%s",
+			obj.GetNamespace(), obj.GetKind(), obj.GetName())
+	}
+	return fmt.Errorf("failed to apply %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+}
@@ -0,0 +1,197 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/scheme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const applicationWithoutScale = `
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: web
+spec:
+  containers:
+  - name: app
+    image: nginx:latest
+    ports:
+    - containerPort: 80
+`
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not implement.
+var applyAsCreateOrUpdate = interceptor.Funcs{
+	Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+		if patch.Type() != types.ApplyPatchType {
+			return cl.Patch(ctx, obj, patch, opts...)
+		}
+		err := cl.Create(ctx, obj)
+		if apierrors.IsAlreadyExists(err) {
+			live := obj.DeepCopyObject().(client.Object)
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+				return err
+			}
+			obj.SetResourceVersion(live.GetResourceVersion())
+			return cl.Update(ctx, obj)
+		}
+		return err
+	},
+}
+
+var _ = Describe("fcp apply", func() {
+	var (
+		ctx     context.Context
+		streams genericiooptions.IOStreams
+		out     *bytes.Buffer
+		dir     string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		dir = GinkgoT().TempDir()
+	})
+
+	writeManifest := func(content string) string {
+		path := filepath.Join(dir, "manifest.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("should print the defaulted Application on a client dry run", func() {
+		o := &Options{
+			Filenames: []string{writeManifest(applicationWithoutScale)},
+			DryRun:    dryRunClient,
+			Namespace: "my-workspace",
+			IOStreams: streams,
+		}
+		Expect(o.Validate()).To(Succeed())
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("namespace: my-workspace"))
+		Expect(out.String()).To(ContainSubstring("minReplicas: 0"))
+		Expect(out.String()).To(ContainSubstring("maxReplicas: 1"))
+		Expect(out.String()).To(ContainSubstring("metric: concurrency"))
+		Expect(out.String()).NotTo(ContainSubstring("status:"))
+	})
+
+	It("should apply the defaulted Application", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithInterceptorFuncs(applyAsCreateOrUpdate).Build()
+		o := &Options{
+			Filenames: []string{writeManifest(applicationWithoutScale)},
+			DryRun:    dryRunNone,
+			Namespace: "my-workspace",
+			IOStreams: streams,
+			Client:    c,
+		}
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Application/web applied"))
+
+		app := &workloadv1alpha1.Application{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "my-workspace", Name: "web"}, app)).To(Succeed())
+		Expect(*app.Spec.Scale.MinReplicas).To(Equal(workloadv1alpha1.DefaultMinReplicas))
+		Expect(*app.Spec.Scale.MaxReplicas).To(Equal(workloadv1alpha1.DefaultMaxReplicas))
+		Expect(app.Spec.RolloutDuration.Duration).To(Equal(workloadv1alpha1.DefaultRolloutDuration))
+	})
+
+	It("should apply the defaults of the workspace to new Applications only", func() {
+		ws := &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-workspace"},
+			Spec: tenancyv1alpha1.WorkspaceSpec{
+				ApplicationDomainTemplate: "{{.Name}}.{{.Workspace}}.apps.example.com",
+				DefaultContainerResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			},
+		}
+		existing := &workloadv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "my-workspace", CreationTimestamp: metav1.Now()},
+			Spec: workloadv1alpha1.ApplicationSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "nginx:latest"}},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(ws, existing).
+			WithInterceptorFuncs(applyAsCreateOrUpdate).Build()
+		o := &Options{
+			Filenames: []string{writeManifest(applicationWithoutScale + `---
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: existing
+spec:
+  containers:
+  - name: app
+    image: nginx:latest
+    ports:
+    - containerPort: 80
+`)},
+			DryRun:    dryRunNone,
+			Namespace: "my-workspace",
+			IOStreams: streams,
+			Client:    c,
+		}
+		Expect(o.Run(ctx)).To(Succeed())
+
+		app := &workloadv1alpha1.Application{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "my-workspace", Name: "web"}, app)).To(Succeed())
+		Expect(app.Spec.Domains).To(ConsistOf("web.my-workspace.apps.example.com"))
+		Expect(app.Spec.Containers[0].Resources.Requests).To(HaveKey(corev1.ResourceCPU))
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(existing), app)).To(Succeed())
+		Expect(app.Spec.Domains).To(BeEmpty())
+		Expect(app.Spec.Containers[0].Resources.Requests).To(BeEmpty())
+	})
+
+	It("should reject an invalid Application before contacting the cluster", func() {
+		o := &Options{
+			Filenames: []string{writeManifest(`
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: empty
+spec: {}
+`)},
+			DryRun:    dryRunClient,
+			Namespace: "my-workspace",
+			IOStreams: streams,
+		}
+		Expect(o.Run(ctx)).To(MatchError(ErrInvalidApplication))
+	})
+
+	It("should report a missing workspace", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				return apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, obj.GetNamespace())
+			},
+		}).Build()
+		o := &Options{
+			Filenames: []string{writeManifest(applicationWithoutScale)},
+			DryRun:    dryRunNone,
+			Namespace: "missing",
+			IOStreams: streams,
+			Client:    c,
+		}
+		Expect(o.Run(ctx)).To(MatchError(ContainSubstring(`workspace "missing" does not exist`)))
+	})
+
+	It("should reject an unknown dry-run mode", func() {
+		o := &Options{Filenames: []string{"app.yaml"}, DryRun: "maybe"}
+		Expect(o.Validate()).To(MatchError(ContainSubstring("invalid --dry-run value")))
+	})
+})
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	fcpconfig "go.funccloud.dev/fcp/internal/config"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	configLong = templates.LongDesc(i18n.T(`
+		Manage the persistent flag defaults stored in $HOME/.fcp/config.yaml.
+
+		A flag given on the command line always wins. Otherwise its value comes from
+		the FCP_<COMMAND>_<FLAG> environment variable, e.g. FCP_INSTALL_DOMAIN, then
+		from the config file, and finally from the built-in default.`))
+
+	configExample = templates.Examples(i18n.T(`
+		# Install with the same domain every time
+		fcp config set install.domain apps.example.com
+
+		# Show the stored defaults
+		fcp config view
+
+		# Remove a stored default
+		fcp config set install.domain ""`))
+)
+
+type Options struct {
+	Path  string
+	Key   string
+	Value string
+	genericiooptions.IOStreams
+}
+
+func NewCmdConfig(ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config SUBCOMMAND",
+		Short:   i18n.T("Manage the persistent flag defaults of fcp"),
+		Long:    configLong,
+		Example: configExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.DefaultSubCommandRun(ioStreams.ErrOut)(cmd, args)
+		},
+	}
+	cmd.AddCommand(NewCmdConfigView(ioStreams))
+	cmd.AddCommand(NewCmdConfigSet(ioStreams))
+	return cmd
+}
+
+func NewCmdConfigView(ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{Path: fcpconfig.GetConfigFile(), IOStreams: ioStreams}
+	return &cobra.Command{
+		Use:   "view",
+		Short: i18n.T("Display the stored flag defaults"),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.RunView())
+		},
+	}
+}
+
+func NewCmdConfigSet(ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{Path: fcpconfig.GetConfigFile(), IOStreams: ioStreams}
+	keys := make([]string, 0, len(fcpconfig.Keys))
+	for key := range fcpconfig.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return &cobra.Command{
+		Use:       "set KEY VALUE",
+		Short:     i18n.T("Store a flag default, an empty value removes it"),
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: keys,
+		Run: func(cmd *cobra.Command, args []string) {
+			o.Key, o.Value = args[0], args[1]
+			cmdutil.CheckErr(o.RunSet())
+		},
+	}
+}
+
+func (o *Options) RunView() error {
+	cfg, err := fcpconfig.Load(o.Path)
+	if err != nil {
+		return err
+	}
+	if len(cfg) == 0 {
+		_, _ = fmt.Fprintf(o.Out, "No defaults stored in %s\n", o.Path)
+		return nil
+	}
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = o.Out.Write(out)
+	return err
+}
+
+func (o *Options) RunSet() error {
+	cfg, err := fcpconfig.Load(o.Path)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Set(o.Key, o.Value); err != nil {
+		return err
+	}
+	if err := cfg.Save(o.Path); err != nil {
+		return err
+	}
+	if o.Value == "" {
+		_, _ = fmt.Fprintf(o.Out, "Removed %s\n", o.Key)
+		return nil
+	}
+	_, _ = fmt.Fprintf(o.Out, "Set %s to %q\n", o.Key, o.Value)
+	return nil
+}
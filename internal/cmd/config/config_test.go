@@ -0,0 +1,43 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		o   *Options
+		out *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		streams, _, stdout, _ := genericiooptions.NewTestIOStreams()
+		out = stdout
+		o = &Options{
+			Path:      filepath.Join(GinkgoT().TempDir(), "config.yaml"),
+			IOStreams: streams,
+		}
+	})
+
+	It("should view the defaults stored by set", func() {
+		Expect(o.RunView()).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("No defaults stored"))
+
+		o.Key, o.Value = "install.domain", "apps.example.com"
+		Expect(o.RunSet()).To(Succeed())
+		Expect(out.String()).To(ContainSubstring(`Set install.domain to "apps.example.com"`))
+
+		Expect(o.RunView()).To(Succeed())
+		Expect(out.String()).To(HaveSuffix("install.domain: apps.example.com\n"))
+	})
+
+	It("should refuse unknown keys", func() {
+		o.Key, o.Value = "install.unknown", "x"
+		Expect(o.RunSet()).To(MatchError(ContainSubstring(`unknown config key "install.unknown"`)))
+	})
+})
@@ -0,0 +1,46 @@
+// Package confirm asks for confirmation before destructive commands run.
+package confirm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// ErrNotTerminal is returned when confirmation is required but there is no terminal to ask on.
+var ErrNotTerminal = errors.New("confirmation required but stdin is not a terminal, pass --yes to proceed")
+
+// isTerminal is replaced in tests to simulate an interactive session.
+var isTerminal = printers.IsTerminal
+
+// AddFlag registers the --yes flag that skips the confirmation prompt.
+func AddFlag(cmd *cobra.Command, yes *bool) {
+	cmd.Flags().BoolVarP(yes, "yes", "y", *yes, "Proceed without asking for confirmation")
+}
+
+// Prompt asks question on the terminal and reports whether the user answered yes.
+// With yes set it agrees without asking. Without a terminal it fails closed with ErrNotTerminal,
+// so scripts never run a destructive command by accident.
+func Prompt(streams genericiooptions.IOStreams, question string, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	if !isTerminal(streams.In) {
+		return false, ErrNotTerminal
+	}
+	_, _ = fmt.Fprintf(streams.Out, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(streams.In).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
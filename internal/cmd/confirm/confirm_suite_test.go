@@ -0,0 +1,14 @@
+package confirm
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfirm(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Confirm Suite")
+}
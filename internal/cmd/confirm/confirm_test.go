@@ -0,0 +1,51 @@
+package confirm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var _ = Describe("Prompt", func() {
+	var terminal bool
+
+	BeforeEach(func() {
+		terminal = false
+		original := isTerminal
+		isTerminal = func(any) bool { return terminal }
+		DeferCleanup(func() { isTerminal = original })
+	})
+
+	It("should proceed without asking when --yes is set", func() {
+		streams, _, out, _ := genericiooptions.NewTestIOStreams()
+		ok, err := Prompt(streams, "Delete everything?", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should ask on a terminal and follow the answer", func() {
+		terminal = true
+		streams, in, out, _ := genericiooptions.NewTestIOStreams()
+		in.WriteString("Yes\n")
+		ok, err := Prompt(streams, "Delete everything?", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(out.String()).To(Equal("Delete everything? [y/N]: "))
+
+		streams, in, _, _ = genericiooptions.NewTestIOStreams()
+		in.WriteString("\n")
+		ok, err = Prompt(streams, "Delete everything?", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should abort without a terminal when --yes is not set", func() {
+		streams, in, out, _ := genericiooptions.NewTestIOStreams()
+		in.WriteString("y\n")
+		ok, err := Prompt(streams, "Delete everything?", false)
+		Expect(err).To(MatchError(ErrNotTerminal))
+		Expect(ok).To(BeFalse())
+		Expect(out.String()).To(BeEmpty())
+	})
+})
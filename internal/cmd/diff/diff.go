@@ -0,0 +1,116 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/controller/workload"
+	"go.funccloud.dev/fcp/internal/scheme"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	diffLong = templates.LongDesc(i18n.T(`
+		Show how the live Knative Service of an Application differs from the one
+		the controller would write.
+
+		The desired Knative Service is computed from the Application spec with the
+		same logic the controller uses, and compared field by field with the live
+		one. Lines starting with - are live values, lines starting with + are the
+		values the next reconcile would set.`))
+
+	diffExample = templates.Examples(i18n.T(`
+		# Show the drift of the web application
+		fcp diff web`))
+)
+
+type Options struct {
+	Application string
+	Namespace   string
+	// RevisionLabelPrefixes mirrors the --revision-label-prefixes flag of the manager.
+	RevisionLabelPrefixes []string
+	genericiooptions.IOStreams
+	Client client.Client
+}
+
+func NewCmdDiff(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{
+		IOStreams: ioStreams,
+	}
+	cmd := &cobra.Command{
+		Use:     "diff APPLICATION",
+		Short:   i18n.T("Diff the live Knative Service of an Application against the desired one"),
+		Long:    diffLong,
+		Example: diffExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.RevisionLabelPrefixes, "revision-label-prefixes", nil,
+		"Label key prefixes the manager copies from Applications onto revisions, as set by its --revision-label-prefixes flag")
+	return cmd
+}
+
+func (o *Options) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	o.Application = args[0]
+	var err error
+	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	cfg, err := f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	scheme.AddKnative()
+	o.Client, err = client.New(cfg, client.Options{
+		Scheme: scheme.Get(),
+	})
+	return err
+}
+
+func (o *Options) Run(ctx context.Context) error {
+	key := client.ObjectKey{Namespace: o.Namespace, Name: o.Application}
+	app := &workloadv1alpha1.Application{}
+	if err := o.Client.Get(ctx, key, app); err != nil {
+		return fmt.Errorf("failed to get application %q: %w", o.Application, err)
+	}
+
+	live := &servingv1.Service{}
+	if err := o.Client.Get(ctx, key, live); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get knative service %q: %w", o.Application, err)
+		}
+		_, _ = fmt.Fprintf(o.Out, "knative service %q does not exist yet, it will be created\n", o.Application)
+		return nil
+	}
+
+	opts, err := workload.NewRevisionOptions(ctx, o.Client, app, o.RevisionLabelPrefixes)
+	if err != nil {
+		return err
+	}
+	desired, err := workload.DesiredKnativeService(app, live, o.Client.Scheme(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to compute the desired knative service: %w", err)
+	}
+	diff, err := workload.KnativeServiceDiff(live, desired)
+	if err != nil {
+		return fmt.Errorf("failed to diff knative service %q: %w", o.Application, err)
+	}
+	if diff == "" {
+		_, _ = fmt.Fprintf(o.Out, "application/%s: no differences\n", o.Application)
+		return nil
+	}
+	_, _ = fmt.Fprint(o.Out, diff)
+	return nil
+}
@@ -0,0 +1,96 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/controller/workload"
+	"go.funccloud.dev/fcp/internal/scheme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/utils/ptr"
+	"knative.dev/serving/pkg/apis/serving"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const workspace = "diff-ws"
+
+var _ = Describe("fcp diff", func() {
+	var (
+		ctx context.Context
+		out *bytes.Buffer
+		app *workloadv1alpha1.Application
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme.AddKnative()
+		app = &workloadv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: workspace, UID: "web-uid"},
+			Spec: workloadv1alpha1.ApplicationSpec{
+				Containers: []corev1.Container{{
+					Image: "nginx:1.27",
+					Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+				}},
+				Scale: workloadv1alpha1.Scale{
+					MinReplicas: ptr.To[int32](0),
+					MaxReplicas: ptr.To[int32](1),
+				},
+				RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+				EnableTLS:       ptr.To(true),
+			},
+		}
+	})
+
+	newOptions := func(objs ...client.Object) *Options {
+		var streams genericiooptions.IOStreams
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		return &Options{
+			Application: "web",
+			Namespace:   workspace,
+			IOStreams:   streams,
+			Client:      fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(objs...).Build(),
+		}
+	}
+
+	desired := func() *servingv1.Service {
+		ksvc, err := workload.DesiredKnativeService(app, nil, scheme.Get(), workload.RevisionOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return ksvc
+	}
+
+	It("should report no differences for a Knative Service in sync", func() {
+		o := newOptions(app, desired())
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(out.String()).To(Equal("application/web: no differences\n"))
+	})
+
+	It("should print the fields that drifted from the Application", func() {
+		live := desired()
+		live.Spec.Template.Spec.Containers[0].Image = "nginx:hotfix"
+		live.Annotations[serving.RolloutDurationKey] = "0s"
+		o := newOptions(app, live)
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("nginx:hotfix"))
+		Expect(out.String()).To(ContainSubstring("nginx:1.27"))
+		Expect(out.String()).To(ContainSubstring(serving.RolloutDurationKey))
+		Expect(out.String()).NotTo(ContainSubstring("no differences"))
+	})
+
+	It("should report a Knative Service that does not exist yet", func() {
+		o := newOptions(app)
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("does not exist yet"))
+	})
+
+	It("should fail for an unknown Application", func() {
+		o := newOptions()
+		Expect(o.Run(ctx)).To(MatchError(ContainSubstring(`failed to get application "web"`)))
+	})
+})
@@ -0,0 +1,194 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.funccloud.dev/fcp/internal/cmd/confirm"
+	"go.funccloud.dev/fcp/internal/scheme"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"knative.dev/serving/pkg/apis/serving"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	drainLong = templates.LongDesc(i18n.T(`
+		Evict the pods of an Application running on a node.
+
+		Pods are removed through the eviction API, so PodDisruptionBudgets are
+		respected: an eviction refused by a budget is retried until it is allowed
+		or --timeout expires. Knative replaces the evicted pods; cordon the node
+		first so that they are scheduled elsewhere.
+
+		The pods to evict are listed for confirmation first; pass --yes to skip
+		the prompt, which is required when stdin is not a terminal.`))
+
+	drainExample = templates.Examples(i18n.T(`
+		# Move the pods of the web application off node worker-1
+		kubectl cordon worker-1
+		fcp drain web --node worker-1
+
+		# Drain without asking for confirmation, e.g. from a script
+		fcp drain web --node worker-1 --yes`))
+)
+
+const (
+	defaultTimeout = 5 * time.Minute
+	retryInterval  = 5 * time.Second
+)
+
+type Options struct {
+	Application string
+	Node        string
+	Namespace   string
+	Timeout     time.Duration
+	Yes         bool
+	genericiooptions.IOStreams
+	Client client.Client
+
+	retryInterval time.Duration
+}
+
+func NewCmdDrain(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{
+		Timeout:       defaultTimeout,
+		IOStreams:     ioStreams,
+		retryInterval: retryInterval,
+	}
+	cmd := &cobra.Command{
+		Use:     "drain APPLICATION --node NODE",
+		Short:   i18n.T("Evict the pods of an Application from a node"),
+		Long:    drainLong,
+		Example: drainExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Node, "node", o.Node, "Name of the node to evict the Application pods from")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "How long to keep retrying evictions refused by a PodDisruptionBudget")
+	confirm.AddFlag(cmd, &o.Yes)
+	return cmd
+}
+
+func (o *Options) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	o.Application = args[0]
+	var err error
+	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	cfg, err := f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.Client, err = client.New(cfg, client.Options{
+		Scheme: scheme.Get(),
+	})
+	return err
+}
+
+func (o *Options) Validate() error {
+	if o.Application == "" {
+		return fmt.Errorf("an application name is required")
+	}
+	if o.Node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	return nil
+}
+
+func (o *Options) Run(ctx context.Context) error {
+	pods := &corev1.PodList{}
+	if err := o.Client.List(ctx, pods, client.InNamespace(o.Namespace),
+		client.MatchingLabels{serving.ServiceLabelKey: o.Application}); err != nil {
+		return fmt.Errorf("failed to list pods of application %q: %w", o.Application, err)
+	}
+	selected := podsOnNode(pods.Items, o.Node)
+	if len(selected) == 0 {
+		_, _ = fmt.Fprintf(o.Out, "No pods of application %q on node %q\n", o.Application, o.Node)
+		return nil
+	}
+	for _, pod := range selected {
+		_, _ = fmt.Fprintf(o.Out, "pod/%s\n", pod.Name)
+	}
+	ok, err := confirm.Prompt(o.IOStreams, fmt.Sprintf("Evict %d pods of application %q from node %q?",
+		len(selected), o.Application, o.Node), o.Yes)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		_, _ = fmt.Fprintln(o.Out, "Drain aborted")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	for i := range selected {
+		if err := o.evict(ctx, &selected[i]); err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintf(o.Out, "application/%s drained from node %s\n", o.Application, o.Node)
+	return nil
+}
+
+// evict requests the eviction of the pod, retrying while a PodDisruptionBudget refuses it.
+func (o *Options) evict(ctx context.Context, pod *corev1.Pod) error {
+	err := wait.PollUntilContextCancel(ctx, o.retryInterval, true, func(ctx context.Context) (bool, error) {
+		err := o.Client.SubResource("eviction").Create(ctx, pod, newEviction(pod))
+		switch {
+		case err == nil:
+			_, _ = fmt.Fprintf(o.Out, "pod/%s evicted\n", pod.Name)
+			return true, nil
+		case apierrors.IsNotFound(err):
+			return true, nil
+		case apierrors.IsTooManyRequests(err):
+			_, _ = fmt.Fprintf(o.ErrOut, "pod/%s eviction refused by a PodDisruptionBudget, retrying\n", pod.Name)
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to evict pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// podsOnNode returns the pods scheduled on the node that are not already being deleted.
+func podsOnNode(pods []corev1.Pod, node string) []corev1.Pod {
+	var selected []corev1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName == node && pod.DeletionTimestamp == nil {
+			selected = append(selected, pod)
+		}
+	}
+	return selected
+}
+
+// newEviction returns the eviction request for the pod.
+func newEviction(pod *corev1.Pod) *policyv1.Eviction {
+	return &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+}
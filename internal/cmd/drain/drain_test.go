@@ -0,0 +1,144 @@
+package drain
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/cmd/confirm"
+	"go.funccloud.dev/fcp/internal/scheme"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"knative.dev/serving/pkg/apis/serving"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const workspace = "drain-ws"
+
+func newPod(name, app, node string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: workspace,
+			Labels:    map[string]string{serving.ServiceLabelKey: app},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+	}
+}
+
+var _ = Describe("fcp drain", func() {
+	var (
+		ctx       context.Context
+		streams   genericiooptions.IOStreams
+		evictions []*policyv1.Eviction
+		refusals  int
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		streams, _, _, _ = genericiooptions.NewTestIOStreams()
+		evictions = nil
+		refusals = 0
+	})
+
+	newOptions := func(objs ...client.Object) *Options {
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string,
+					obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					if subResourceName == "eviction" {
+						if refusals > 0 {
+							refusals--
+							return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+						}
+						evictions = append(evictions, subResource.(*policyv1.Eviction).DeepCopy())
+						return c.Delete(ctx, obj)
+					}
+					return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+				},
+			}).
+			Build()
+		return &Options{
+			Application:   "web",
+			Node:          "worker-1",
+			Namespace:     workspace,
+			Timeout:       time.Second,
+			Yes:           true,
+			IOStreams:     streams,
+			Client:        k8sClient,
+			retryInterval: 10 * time.Millisecond,
+		}
+	}
+
+	It("should select only the running pods on the node", func() {
+		terminating := newPod("web-3", "web", "worker-1")
+		terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		pods := []corev1.Pod{
+			*newPod("web-1", "web", "worker-1"),
+			*newPod("web-2", "web", "worker-2"),
+			*terminating,
+		}
+		selected := podsOnNode(pods, "worker-1")
+		Expect(selected).To(HaveLen(1))
+		Expect(selected[0].Name).To(Equal("web-1"))
+	})
+
+	It("should build the eviction request for the pod", func() {
+		eviction := newEviction(newPod("web-1", "web", "worker-1"))
+		Expect(eviction.Name).To(Equal("web-1"))
+		Expect(eviction.Namespace).To(Equal(workspace))
+		Expect(eviction.DeleteOptions).To(BeNil())
+	})
+
+	It("should evict the Application pods on the node only", func() {
+		o := newOptions(
+			newPod("web-1", "web", "worker-1"),
+			newPod("web-2", "web", "worker-2"),
+			newPod("api-1", "api", "worker-1"),
+		)
+		Expect(o.Validate()).To(Succeed())
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(evictions).To(HaveLen(1))
+		Expect(evictions[0].Name).To(Equal("web-1"))
+
+		Expect(o.Client.Get(ctx, client.ObjectKey{Namespace: workspace, Name: "web-2"}, &corev1.Pod{})).To(Succeed())
+		Expect(o.Client.Get(ctx, client.ObjectKey{Namespace: workspace, Name: "api-1"}, &corev1.Pod{})).To(Succeed())
+	})
+
+	It("should retry evictions refused by a PodDisruptionBudget", func() {
+		refusals = 2
+		o := newOptions(newPod("web-1", "web", "worker-1"))
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(evictions).To(HaveLen(1))
+		Expect(refusals).To(BeZero())
+	})
+
+	It("should give up when the budget never allows the eviction", func() {
+		refusals = 1000
+		o := newOptions(newPod("web-1", "web", "worker-1"))
+		o.Timeout = 100 * time.Millisecond
+		Expect(o.Run(ctx)).To(MatchError(ContainSubstring("failed to evict pod web-1")))
+		Expect(evictions).To(BeEmpty())
+	})
+
+	It("should not evict anything without confirmation", func() {
+		o := newOptions(newPod("web-1", "web", "worker-1"))
+		o.Yes = false
+		Expect(o.Run(ctx)).To(MatchError(confirm.ErrNotTerminal))
+		Expect(evictions).To(BeEmpty())
+		Expect(o.Client.Get(ctx, client.ObjectKey{Namespace: workspace, Name: "web-1"}, &corev1.Pod{})).To(Succeed())
+	})
+
+	It("should require a node", func() {
+		o := &Options{Application: "web", Timeout: time.Second}
+		Expect(o.Validate()).To(MatchError(ContainSubstring("--node is required")))
+	})
+})
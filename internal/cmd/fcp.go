@@ -12,8 +12,15 @@ import (
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"go.funccloud.dev/fcp/internal/cmd/apply"
+	"go.funccloud.dev/fcp/internal/cmd/config"
+	"go.funccloud.dev/fcp/internal/cmd/diff"
+	"go.funccloud.dev/fcp/internal/cmd/drain"
 	"go.funccloud.dev/fcp/internal/cmd/install"
 	"go.funccloud.dev/fcp/internal/cmd/plugin"
+	"go.funccloud.dev/fcp/internal/cmd/status"
+	"go.funccloud.dev/fcp/internal/cmd/validate"
+	"go.funccloud.dev/fcp/internal/cmd/workspace"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/client-go/rest"
@@ -381,6 +388,13 @@ func NewFCPCommand(o FCPOptions) *cobra.Command {
 	cmds.AddCommand(plugin.NewCmdPlugin(o.IOStreams))
 	cmds.AddCommand(version.NewCmdVersion(f, o.IOStreams))
 	cmds.AddCommand(install.NewCmdInstall(f, o.IOStreams))
+	cmds.AddCommand(validate.NewCmdValidate(o.IOStreams))
+	cmds.AddCommand(apply.NewCmdApply(f, o.IOStreams))
+	cmds.AddCommand(drain.NewCmdDrain(f, o.IOStreams))
+	cmds.AddCommand(diff.NewCmdDiff(f, o.IOStreams))
+	cmds.AddCommand(config.NewCmdConfig(o.IOStreams))
+	cmds.AddCommand(workspace.NewCmdWorkspace(f, o.IOStreams))
+	cmds.AddCommand(status.NewCmdStatus(f, o.IOStreams))
 
 	// Stop warning about normalization of flags. That makes it possible to
 	// add the klog flags later.
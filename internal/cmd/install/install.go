@@ -3,11 +3,17 @@ package install
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.funccloud.dev/fcp/internal/cmd/plugin"
+	"go.funccloud.dev/fcp/internal/config"
 	"go.funccloud.dev/fcp/internal/resource"
+	"go.funccloud.dev/fcp/internal/resource/knative"
 	"go.funccloud.dev/fcp/internal/scheme"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -15,7 +21,20 @@ import (
 )
 
 type Options struct {
-	Domain string
+	Domain  string
+	Upgrade bool
+	Force   bool
+	Quiet   bool
+	// Kind overrides the Kind cluster detection when set.
+	Kind *bool
+
+	// MetricsScraperServiceAccount is bound to the fcp-metrics-reader ClusterRole when set, as namespace:name.
+	MetricsScraperServiceAccount string
+
+	RegistriesSkippingTagResolving []string
+	RevisionTimeout                time.Duration
+	KnativeFeatures                map[string]string
+
 	genericiooptions.IOStreams
 	Client client.Client
 }
@@ -27,19 +46,46 @@ func NewCmdInstall(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cob
 	cmd := &cobra.Command{
 		Use:   "install",
 		Short: i18n.T("Install the FCP components"),
-		Long:  i18n.T("Install the FCP components in the current context."),
+		Long: i18n.T(`Install the FCP components in the current context.
+
+An existing installation is not re-applied; run with --upgrade to move it to the component
+versions and Knative Serving config of this release.
+
+A component that fails does not stop the others; only the components depending on it are skipped.
+A summary of the succeeded, failed and skipped components is printed at the end. When some components
+succeeded the command exits with code 2, and running it again with --upgrade applies the rest.`),
 		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(config.ApplyFlagDefaults(cmd.Flags(), "install"))
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate())
 			cmdutil.CheckErr(o.Run(cmd.Context()))
 		},
 	}
 
-	cmd.Flags().StringVar(&o.Domain, "domain", "", "Domain for FCP")
+	cmd.Flags().StringVar(&o.Domain, "domain", "", "Domain for FCP, defaults to FCP_INSTALL_DOMAIN or the install.domain config key")
+	cmd.Flags().BoolVar(&o.Upgrade, "upgrade", false, "Upgrade an existing installation, applying only components whose version changed")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Allow --upgrade to downgrade components")
+	cmd.Flags().BoolVarP(&o.Quiet, "quiet", "q", false, "Only print errors")
+	cmd.Flags().Bool("kind", false, "Treat the cluster as a Kind cluster, which gets the Knative default domain and NodePort ingress; detected from the kindnet DaemonSet when unset")
+	cmd.Flags().StringVar(&o.MetricsScraperServiceAccount, "metrics-scraper-service-account", "",
+		"Service account allowed to scrape the manager metrics, as namespace:name, e.g. monitoring:prometheus")
+	cmd.Flags().StringSliceVar(&o.RegistriesSkippingTagResolving, "registries-skipping-tag-resolving", nil,
+		"Registries whose image tags Knative does not resolve to digests, e.g. kind.local,dev.local")
+	cmd.Flags().DurationVar(&o.RevisionTimeout, "revision-timeout", 0,
+		"Default request timeout of application revisions, e.g. 15m (defaults to Knative's 5m)")
+	cmd.Flags().StringToStringVar(&o.KnativeFeatures, "knative-feature", nil,
+		"Knative feature flags to set, e.g. kubernetes.podspec-init-containers=enabled,kubernetes.podspec-affinity=enabled")
 	return cmd
 }
 
 func (o *Options) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("kind") {
+		kind, err := cmd.Flags().GetBool("kind")
+		if err != nil {
+			return err
+		}
+		o.Kind = &kind
+	}
 	cfg, err := f.ToRESTConfig()
 	if err != nil {
 		return err
@@ -57,12 +103,34 @@ func (o *Options) Validate() error {
 	if o.Domain == "" {
 		return fmt.Errorf("domain flag is required")
 	}
-	return nil
+	if o.Force && !o.Upgrade {
+		return fmt.Errorf("--force can only be used with --upgrade")
+	}
+	if _, err := o.metricsScraper(); err != nil {
+		return err
+	}
+	return o.servingConfig().Validate()
 }
 
 func (o *Options) Run(ctx context.Context) error {
+	if o.Quiet {
+		o.Out = io.Discard
+	}
+	scraper, err := o.metricsScraper()
+	if err != nil {
+		return err
+	}
+	if o.Upgrade {
+		_, _ = fmt.Fprintf(o.Out, "Upgrading FCP components with domain %s\n", o.Domain)
+		if err := resource.Upgrade(ctx, o.Domain, plugin.GetDir(), o.Force, o.Kind, o.servingConfig(), scraper, o.Client, o.IOStreams); err != nil {
+			_, _ = fmt.Fprintf(o.ErrOut, "Error upgrading FCP components: %v\n", err)
+			return err
+		}
+		_, _ = fmt.Fprintf(o.Out, "FCP components upgraded successfully\n")
+		return nil
+	}
 	_, _ = fmt.Fprintf(o.Out, "Installing FCP components with domain %s\n", o.Domain)
-	err := resource.CheckOrInstallVersion(ctx, o.Domain, plugin.GetDir(), o.Client, o.IOStreams)
+	err = resource.CheckOrInstallVersion(ctx, o.Domain, plugin.GetDir(), o.Kind, o.servingConfig(), scraper, o.Client, o.IOStreams)
 	if err != nil {
 		_, _ = fmt.Fprintf(o.ErrOut, "Error installing FCP components: %v\n", err)
 		return err
@@ -70,3 +138,27 @@ func (o *Options) Run(ctx context.Context) error {
 	_, _ = fmt.Fprintf(o.Out, "FCP components installed successfully\n")
 	return nil
 }
+
+// servingConfig returns the KnativeServing config requested through the install flags. It is applied
+// on install and upgrade, and to an already installed Knative Serving whenever one of the flags is set.
+// The rendered spec replaces the current one, so flags of earlier runs must be repeated to be kept.
+func (o *Options) servingConfig() knative.ServingConfig {
+	return knative.ServingConfig{
+		RegistriesSkippingTagResolving: o.RegistriesSkippingTagResolving,
+		RevisionTimeout:                o.RevisionTimeout,
+		Features:                       o.KnativeFeatures,
+	}
+}
+
+// metricsScraper parses the --metrics-scraper-service-account flag. It is empty when the flag is unset.
+func (o *Options) metricsScraper() (types.NamespacedName, error) {
+	if o.MetricsScraperServiceAccount == "" {
+		return types.NamespacedName{}, nil
+	}
+	namespace, name, ok := strings.Cut(o.MetricsScraperServiceAccount, ":")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("--metrics-scraper-service-account must be namespace:name, got %q",
+			o.MetricsScraperServiceAccount)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
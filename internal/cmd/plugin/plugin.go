@@ -2,16 +2,19 @@ package plugin
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.funccloud.dev/fcp/internal/config"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -32,7 +35,13 @@ var (
 		kubectl plugin list
 		
 		# List only binary names of available plugins without paths
-		kubectl plugin list --name-only`))
+		kubectl plugin list --name-only
+
+		# List only the plugins that run, hiding those shadowed further down the PATH
+		fcp plugin list --effective
+
+		# Show which file runs for "fcp hello"
+		fcp plugin which hello`))
 
 	pluginListLong = templates.LongDesc(i18n.T(`
 		List all available plugin files on a user's PATH or in plugins directory $HOME/.fcp/pluguins.
@@ -42,6 +51,9 @@ var (
 		- executable
 		- anywhere on the user's PATH or in plugins directory $HOME/.fcp/plugins
 		- begin with "fcp-"
+
+		Plugins answering the __fcp_plugin_info argument with a JSON object holding
+		their "version" and "description" are listed with them.
 `))
 
 	ValidPluginFilenamePrefixes = []string{"fcp"}
@@ -83,12 +95,17 @@ func NewCmdPlugin(streams genericiooptions.IOStreams) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCmdPluginList(streams))
+	cmd.AddCommand(NewCmdPluginWhich(streams))
 	return cmd
 }
 
 type PluginListOptions struct {
 	Verifier    PathVerifier
 	PluginPaths []string
+	// InfoTimeout bounds how long each plugin may take to report its metadata.
+	InfoTimeout time.Duration
+	// Effective only lists the plugin that runs for each name, the first executable on the PATH.
+	Effective bool
 
 	genericiooptions.IOStreams
 }
@@ -96,7 +113,8 @@ type PluginListOptions struct {
 // NewCmdPluginList provides a way to list all plugin executables visible to kubectl
 func NewCmdPluginList(streams genericiooptions.IOStreams) *cobra.Command {
 	o := &PluginListOptions{
-		IOStreams: streams,
+		InfoTimeout: DefaultPluginInfoTimeout,
+		IOStreams:   streams,
 	}
 
 	cmd := &cobra.Command{
@@ -106,9 +124,11 @@ func NewCmdPluginList(streams genericiooptions.IOStreams) *cobra.Command {
 		Long:    pluginListLong,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(cmd))
-			cmdutil.CheckErr(o.Run())
+			cmdutil.CheckErr(o.Run(cmd.Context()))
 		},
 	}
+	cmd.Flags().BoolVar(&o.Effective, "effective", o.Effective,
+		"Only list the plugin that runs for each name, hiding those shadowed further down the PATH")
 	return cmd
 }
 
@@ -122,8 +142,12 @@ func (o *PluginListOptions) Complete(cmd *cobra.Command) error {
 	return nil
 }
 
-func (o *PluginListOptions) Run() error {
+func (o *PluginListOptions) Run(ctx context.Context) error {
 	plugins, pluginErrors := o.ListPlugins()
+	if o.Effective {
+		plugins = EffectivePlugins(plugins)
+	}
+	w := printers.GetNewTabWriter(o.Out)
 
 	if len(plugins) > 0 {
 		fmt.Fprintf(o.Out, "The following compatible plugins are available:\n\n") // nolint:errcheck
@@ -138,7 +162,11 @@ func (o *PluginListOptions) Run() error {
 		if ok {
 			base = name
 		}
-		fmt.Fprintf(o.Out, "%s\n", base) // nolint:errcheck
+		if info, ok := o.pluginInfo(ctx, pluginPath); ok {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", base, info.Version, info.Description) // nolint:errcheck
+		} else {
+			fmt.Fprintf(w, "%s\n", base) // nolint:errcheck
+		}
 		if errs := o.Verifier.Verify(pluginPath); len(errs) != 0 {
 			for _, err := range errs {
 				fmt.Fprintf(o.ErrOut, "  - %s\n", err) // nolint:errcheck
@@ -147,6 +175,10 @@ func (o *PluginListOptions) Run() error {
 		}
 	}
 
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
 	if pluginWarnings > 0 {
 		if pluginWarnings == 1 {
 			pluginErrors = append(pluginErrors, fmt.Errorf("error: one plugin warning was found"))
@@ -165,6 +197,18 @@ func (o *PluginListOptions) Run() error {
 	return nil
 }
 
+// pluginInfo asks fcp plugins for their metadata. Third party binaries such as helm do not know
+// the info argument and are not run.
+func (o *PluginListOptions) pluginInfo(ctx context.Context, path string) (PluginInfo, bool) {
+	if _, ok := ThirdPartyPlugin(filepath.Base(path)); ok {
+		return PluginInfo{}, false
+	}
+	if isExec, err := isExecutable(path); err != nil || !isExec {
+		return PluginInfo{}, false
+	}
+	return GetPluginInfo(ctx, path, o.InfoTimeout)
+}
+
 // ListPlugins returns list of plugin paths.
 func (o *PluginListOptions) ListPlugins() ([]string, []error) {
 	plugins := []string{}
@@ -200,6 +244,25 @@ func (o *PluginListOptions) ListPlugins() ([]string, []error) {
 	return plugins, errors
 }
 
+// EffectivePlugins keeps the plugin that runs for each name: the first executable file with that
+// name in PATH order, as found by the plugin handler. Shadowed and non-executable files are dropped.
+func EffectivePlugins(plugins []string) []string {
+	seen := sets.New[string]()
+	var effective []string
+	for _, path := range plugins {
+		name := filepath.Base(path)
+		if seen.Has(name) {
+			continue
+		}
+		if isExec, err := isExecutable(path); err != nil || !isExec {
+			continue
+		}
+		seen.Insert(name)
+		effective = append(effective, path)
+	}
+	return effective
+}
+
 // pathVerifier receives a path and determines if it is valid or not
 type PathVerifier interface {
 	// Verify determines if a given path is valid
@@ -276,7 +339,15 @@ func isExecutable(fullPath string) (bool, error) {
 // uniquePathsList deduplicates a given slice of strings without
 // sorting or otherwise altering its order in any way.
 func uniquePathsList(paths []string) []string {
-	return sets.NewString(paths...).UnsortedList()
+	seen := sets.New[string]()
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !seen.Has(path) {
+			seen.Insert(path)
+			unique = append(unique, path)
+		}
+	}
+	return unique
 }
 
 func hasValidPrefix(filepath string, validPrefixes []string, binariesCommandMap map[string]string) bool {
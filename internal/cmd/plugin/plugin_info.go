@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"time"
+)
+
+// PluginInfoArg is passed to a plugin to ask for its metadata. A plugin supporting it prints a
+// PluginInfo as JSON on stdout and exits successfully.
+const PluginInfoArg = "__fcp_plugin_info"
+
+// DefaultPluginInfoTimeout bounds how long plugin list waits for a plugin to describe itself.
+const DefaultPluginInfoTimeout = 2 * time.Second
+
+// PluginInfo is the metadata a plugin reports about itself.
+type PluginInfo struct {
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// GetPluginInfo runs the plugin with PluginInfoArg and decodes its metadata. It returns false for
+// plugins that do not support it, fail, or do not answer within the timeout; those are listed by
+// name only.
+func GetPluginInfo(ctx context.Context, path string, timeout time.Duration) (PluginInfo, bool) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, PluginInfoArg)
+	// Do not wait for children of a killed plugin that still hold its output open.
+	cmd.WaitDelay = 100 * time.Millisecond
+	out, err := cmd.Output()
+	if err != nil {
+		return PluginInfo{}, false
+	}
+	var info PluginInfo
+	if err := json.Unmarshal(out, &info); err != nil || info == (PluginInfo{}) {
+		return PluginInfo{}, false
+	}
+	return info, true
+}
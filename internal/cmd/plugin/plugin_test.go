@@ -0,0 +1,116 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var _ = Describe("plugin list", func() {
+	var (
+		dir    string
+		o      *PluginListOptions
+		stdout *bytes.Buffer
+	)
+
+	writePlugin := func(name, script string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		var streams genericiooptions.IOStreams
+		streams, _, stdout, _ = genericiooptions.NewTestIOStreams()
+		root := &cobra.Command{Use: "fcp"}
+		root.AddCommand(&cobra.Command{Use: "version"})
+		o = &PluginListOptions{
+			Verifier: &CommandOverrideVerifier{
+				root:        root,
+				seenPlugins: map[string]string{},
+			},
+			PluginPaths: []string{dir},
+			InfoTimeout: DefaultPluginInfoTimeout,
+			IOStreams:   streams,
+		}
+	})
+
+	It("should show the metadata of plugins reporting it", func() {
+		writePlugin("fcp-hello", `[ "$1" = "`+PluginInfoArg+`" ] && echo '{"version":"v1.2.0","description":"Say hello"}'`)
+		writePlugin("fcp-plain", `echo "plain plugin, no metadata"`)
+
+		Expect(o.Run(context.Background())).To(Succeed())
+		Expect(stdout.String()).To(MatchRegexp(`fcp-hello\s+v1\.2\.0\s+Say hello\n`))
+		Expect(stdout.String()).To(ContainSubstring("fcp-plain\n"))
+	})
+
+	It("should fall back to the name when the plugin fails or hangs", func() {
+		failing := writePlugin("fcp-failing", `exit 1`)
+		slow := writePlugin("fcp-slow", `sleep 5; echo '{"version":"v1"}'`)
+
+		_, ok := GetPluginInfo(context.Background(), failing, time.Second)
+		Expect(ok).To(BeFalse())
+		start := time.Now()
+		_, ok = GetPluginInfo(context.Background(), slow, 100*time.Millisecond)
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+	})
+})
+
+var _ = Describe("shadowed plugins", func() {
+	var (
+		first, second string
+		stdout        *bytes.Buffer
+		stderr        *bytes.Buffer
+		streams       genericiooptions.IOStreams
+	)
+
+	writePlugin := func(dir, name string, mode os.FileMode) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\n"), mode)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		first, second = GinkgoT().TempDir(), GinkgoT().TempDir()
+		streams, _, stdout, stderr = genericiooptions.NewTestIOStreams()
+	})
+
+	It("should resolve the first executable plugin on the PATH", func() {
+		writePlugin(first, "fcp-hello", 0o644)
+		winner := writePlugin(second, "fcp-hello", 0o755)
+		third := GinkgoT().TempDir()
+		shadowed := writePlugin(third, "fcp-hello", 0o755)
+		paths := []string{first, second, third, first}
+
+		o := &PluginWhichOptions{Name: "hello", PluginPaths: paths, IOStreams: streams}
+		Expect(o.Run()).To(Succeed())
+		Expect(stdout.String()).To(Equal(winner + "\n"))
+		Expect(stderr.String()).To(ContainSubstring(shadowed + " is not used, " + winner + " runs instead"))
+	})
+
+	It("should only list the effective plugins", func() {
+		winner := writePlugin(first, "fcp-hello", 0o755)
+		writePlugin(second, "fcp-hello", 0o755)
+		other := writePlugin(second, "fcp-other", 0o755)
+
+		list := &PluginListOptions{PluginPaths: []string{first, second}, IOStreams: streams}
+		plugins, errs := list.ListPlugins()
+		Expect(errs).To(BeEmpty())
+		Expect(plugins).To(HaveLen(3))
+		Expect(EffectivePlugins(plugins)).To(Equal([]string{winner, other}))
+	})
+
+	It("should report a plugin missing from the PATH", func() {
+		o := &PluginWhichOptions{Name: "missing", PluginPaths: []string{first}, IOStreams: streams}
+		Expect(o.Run()).To(MatchError(`plugin "missing" not found in your PATH`))
+	})
+})
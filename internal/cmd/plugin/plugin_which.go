@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var pluginWhichLong = templates.LongDesc(i18n.T(`
+	Show the plugin file that runs for a plugin name.
+
+	When several files with the same name are on the PATH, the first executable one
+	runs; the others are shadowed and reported as warnings.`))
+
+type PluginWhichOptions struct {
+	Name        string
+	PluginPaths []string
+
+	genericiooptions.IOStreams
+}
+
+// NewCmdPluginWhich provides a way to find which plugin executable runs for a name
+func NewCmdPluginWhich(streams genericiooptions.IOStreams) *cobra.Command {
+	o := &PluginWhichOptions{
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "which NAME",
+		Short: i18n.T("Show the plugin executable that runs for a name"),
+		Long:  pluginWhichLong,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(args))
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+	return cmd
+}
+
+func (o *PluginWhichOptions) Complete(args []string) error {
+	o.Name = args[0]
+	SetDirEnv()
+	o.PluginPaths = filepath.SplitList(os.Getenv("PATH"))
+	return nil
+}
+
+func (o *PluginWhichOptions) Run() error {
+	list := &PluginListOptions{PluginPaths: o.PluginPaths, IOStreams: o.IOStreams}
+	plugins, _ := list.ListPlugins()
+	binaries := sets.New[string]()
+	for _, prefix := range ValidPluginFilenamePrefixes {
+		binaries.Insert(prefix + "-" + o.Name)
+	}
+	if binary, ok := ValidSubcommandBinaries[o.Name]; ok {
+		binaries.Insert(binary)
+	}
+	var candidates []string
+	for _, path := range plugins {
+		if binaries.Has(filepath.Base(path)) {
+			candidates = append(candidates, path)
+		}
+	}
+	effective := EffectivePlugins(candidates)
+	if len(effective) == 0 {
+		return fmt.Errorf("plugin %q not found in your PATH", o.Name)
+	}
+	_, _ = fmt.Fprintln(o.Out, effective[0])
+	for _, path := range candidates {
+		if path != effective[0] {
+			_, _ = fmt.Fprintf(o.ErrOut, "warning: %s is not used, %s runs instead\n", path, effective[0])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.funccloud.dev/fcp/internal/resource"
+	"go.funccloud.dev/fcp/internal/scheme"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	statusLong = templates.LongDesc(i18n.T(`
+		Print the readiness of the deployments of the components installed by fcp.
+
+		The command fails when a deployment is missing or not available. With --watch,
+		the deployments are checked again every --interval until all of them are ready,
+		which is useful right after fcp install, or until --timeout expires. The table
+		is printed again whenever the readiness changes.`))
+
+	statusExample = templates.Examples(i18n.T(`
+		# Check whether the platform is healthy
+		fcp status
+
+		# Wait up to 5 minutes for the platform to become healthy after an install
+		fcp install && fcp status --watch --timeout 5m`))
+)
+
+const (
+	defaultInterval = 5 * time.Second
+	defaultTimeout  = 10 * time.Minute
+)
+
+// errNotReady is returned when some deployments are not ready.
+var errNotReady = errors.New("some components are not ready")
+
+type Options struct {
+	Watch    bool
+	Interval time.Duration
+	Timeout  time.Duration
+	genericiooptions.IOStreams
+	Client client.Client
+}
+
+func NewCmdStatus(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{
+		Interval:  defaultInterval,
+		Timeout:   defaultTimeout,
+		IOStreams: ioStreams,
+	}
+	cmd := &cobra.Command{
+		Use:     "status",
+		Short:   i18n.T("Print the readiness of the FCP components"),
+		Long:    statusLong,
+		Example: statusExample,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch, "Check again until every component is ready or --timeout expires")
+	cmd.Flags().DurationVar(&o.Interval, "interval", o.Interval, "How often --watch checks the components")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "How long --watch waits for the components to be ready")
+	return cmd
+}
+
+func (o *Options) Complete(f cmdutil.Factory) error {
+	cfg, err := f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.Client, err = client.New(cfg, client.Options{
+		Scheme: scheme.Get(),
+	})
+	return err
+}
+
+func (o *Options) Validate() error {
+	if o.Interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	return nil
+}
+
+func (o *Options) Run(ctx context.Context) error {
+	if !o.Watch {
+		health, err := resource.CheckHealth(ctx, o.Client)
+		if err != nil {
+			return err
+		}
+		if err := o.print(health); err != nil {
+			return err
+		}
+		if !resource.Healthy(health) {
+			return errNotReady
+		}
+		return nil
+	}
+
+	var last []resource.DeploymentHealth
+	err := wait.PollUntilContextTimeout(ctx, o.Interval, o.Timeout, true, func(ctx context.Context) (bool, error) {
+		health, err := resource.CheckHealth(ctx, o.Client)
+		if err != nil {
+			return false, err
+		}
+		if !reflect.DeepEqual(health, last) {
+			if last != nil {
+				_, _ = fmt.Fprintln(o.Out)
+			}
+			if err := o.print(health); err != nil {
+				return false, err
+			}
+			last = health
+		}
+		return resource.Healthy(health), nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("%w after waiting %s", errNotReady, o.Timeout)
+	}
+	return err
+}
+
+// print writes one line per deployment with its readiness.
+func (o *Options) print(health []resource.DeploymentHealth) error {
+	w := printers.GetNewTabWriter(o.Out)
+	_, _ = fmt.Fprintln(w, "COMPONENT\tNAMESPACE\tDEPLOYMENT\tREADY\tREASON")
+	for _, h := range health {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", h.Component, h.Namespace, h.Name, h.Ready, h.Reason)
+	}
+	return w.Flush()
+}
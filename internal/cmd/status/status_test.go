@@ -0,0 +1,122 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/resource/certmanager"
+	"go.funccloud.dev/fcp/internal/resource/knative"
+	"go.funccloud.dev/fcp/internal/scheme"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newDeployment(nn types.NamespacedName, ready bool) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+	}
+	if ready {
+		setReady(deployment)
+	}
+	return deployment
+}
+
+func setReady(deployment *appsv1.Deployment) {
+	deployment.Status = appsv1.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		AvailableReplicas: 1,
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+		},
+	}
+}
+
+func componentDeployments() []types.NamespacedName {
+	return append(certmanager.Deployments(), knative.Deployments()...)
+}
+
+var _ = Describe("fcp status", func() {
+	var (
+		ctx context.Context
+		out *bytes.Buffer
+		o   *Options
+	)
+
+	newOptions := func(k8sClient client.Client) *Options {
+		var streams genericiooptions.IOStreams
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		return &Options{Interval: 10 * time.Millisecond, Timeout: 5 * time.Second, IOStreams: streams, Client: k8sClient}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should print every deployment and succeed when all are ready", func() {
+		builder := fake.NewClientBuilder().WithScheme(scheme.Get())
+		for _, nn := range componentDeployments() {
+			builder = builder.WithObjects(newDeployment(nn, true))
+		}
+		o = newOptions(builder.Build())
+
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`(?m)^cert-manager\s+cert-manager\s+cert-manager-webhook\s+true`))
+		Expect(out.String()).To(MatchRegexp(`(?m)^knative\s+knative-serving\s+controller\s+true`))
+	})
+
+	It("should fail without --watch when a deployment is missing", func() {
+		o = newOptions(fake.NewClientBuilder().WithScheme(scheme.Get()).Build())
+
+		Expect(o.Run(ctx)).To(MatchError(errNotReady))
+		Expect(out.String()).To(MatchRegexp(`(?m)^knative\s+knative-operator\s+knative-operator\s+false\s+not found$`))
+	})
+
+	It("should watch until every deployment reports ready", func() {
+		builder := fake.NewClientBuilder().WithScheme(scheme.Get())
+		for _, nn := range componentDeployments() {
+			builder = builder.WithObjects(newDeployment(nn, false))
+		}
+		// The deployments become available once they have been checked a few times.
+		gets := 0
+		k8sClient := builder.WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				gets++
+				if gets > 2*len(componentDeployments()) {
+					setReady(obj.(*appsv1.Deployment))
+				}
+				return nil
+			},
+		}).Build()
+		o = newOptions(k8sClient)
+		o.Watch = true
+
+		start := time.Now()
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(out.String()).To(ContainSubstring("not available"))
+		Expect(out.String()).To(MatchRegexp(`(?m)^knative\s+knative-serving\s+webhook\s+true`))
+	})
+
+	It("should give up once --timeout expires", func() {
+		o = newOptions(fake.NewClientBuilder().WithScheme(scheme.Get()).Build())
+		o.Watch = true
+		o.Timeout = 50 * time.Millisecond
+
+		Expect(o.Run(ctx)).To(MatchError(ContainSubstring("some components are not ready after waiting 50ms")))
+	})
+})
@@ -0,0 +1,187 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	webhooktenancyv1alpha1 "go.funccloud.dev/fcp/internal/webhook/tenancy/v1alpha1"
+	webhookworkloadv1alpha1 "go.funccloud.dev/fcp/internal/webhook/workload/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	validateLong = templates.LongDesc(i18n.T(`
+		Validate Application and Workspace manifests locally, without contacting a cluster.
+
+		The same defaulting and validation rules used by the admission webhooks are
+		applied to every Application and Workspace found in the given files. Checks
+		that need a live cluster, such as the existence of the target workspace or
+		the immutability of fields on update, are skipped.`))
+
+	validateExample = templates.Examples(i18n.T(`
+		# Validate an application manifest
+		fcp validate -f app.yaml
+
+		# Validate a workspace manifest
+		fcp validate -f workspace.yaml
+
+		# Validate manifests read from stdin
+		cat app.yaml | fcp validate -f -`))
+)
+
+// ErrInvalidManifest is returned when at least one Application or Workspace failed validation.
+var ErrInvalidManifest = errors.New("one or more resources are invalid")
+
+type Options struct {
+	Filenames []string
+	genericiooptions.IOStreams
+}
+
+func NewCmdValidate(ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &Options{
+		IOStreams: ioStreams,
+	}
+	cmd := &cobra.Command{
+		Use:     "validate -f FILENAME",
+		Short:   i18n.T("Validate Application and Workspace manifests offline"),
+		Long:    validateLong,
+		Example: validateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.Filenames, "filename", "f", o.Filenames, "Files containing the Application and Workspace manifests to validate, use - for stdin")
+	return cmd
+}
+
+func (o *Options) Validate() error {
+	if len(o.Filenames) == 0 {
+		return fmt.Errorf("at least one filename must be specified with -f")
+	}
+	return nil
+}
+
+func (o *Options) Run(ctx context.Context) error {
+	_, _ = fmt.Fprintln(o.ErrOut, "Note: skipping checks that require a cluster (workspace existence)")
+	invalid := false
+	for _, filename := range o.Filenames {
+		data, err := o.readFile(filename)
+		if err != nil {
+			return err
+		}
+		ok, err := o.validateManifest(ctx, filename, data)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			invalid = true
+		}
+	}
+	if invalid {
+		return ErrInvalidManifest
+	}
+	return nil
+}
+
+func (o *Options) readFile(filename string) ([]byte, error) {
+	if filename == "-" {
+		return io.ReadAll(o.In)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	return data, nil
+}
+
+// validateManifest defaults and validates every Application and Workspace in the given manifest.
+// It reports false when at least one of them is invalid.
+func (o *Options) validateManifest(ctx context.Context, filename string, data []byte) (bool, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	valid := true
+	for {
+		obj := &unstructured.Unstructured{}
+		err := decoder.Decode(obj)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return false, fmt.Errorf("failed to decode %s: %w", filename, err)
+		}
+		if obj.Object == nil {
+			continue
+		}
+		var (
+			resource string
+			errs     field.ErrorList
+		)
+		gvk := obj.GroupVersionKind()
+		switch gvk.GroupKind() {
+		case workloadv1alpha1.GroupVersion.WithKind("Application").GroupKind():
+			resource = "application"
+			errs, err = validateApplication(ctx, obj)
+		case tenancyv1alpha1.GroupVersion.WithKind("Workspace").GroupKind():
+			resource = "workspace"
+			errs, err = validateWorkspace(ctx, obj)
+		default:
+			_, _ = fmt.Fprintf(o.ErrOut, "%s: skipping %s %q, only Applications and Workspaces are validated\n",
+				filename, gvk.Kind, obj.GetName())
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to decode %s %q in %s: %w", resource, obj.GetName(), filename, err)
+		}
+		if len(errs) == 0 {
+			_, _ = fmt.Fprintf(o.Out, "%s: %s/%s is valid\n", filename, resource, obj.GetName())
+			continue
+		}
+		valid = false
+		_, _ = fmt.Fprintf(o.Out, "%s: %s/%s is invalid\n", filename, resource, obj.GetName())
+		for _, e := range errs {
+			_, _ = fmt.Fprintf(o.Out, "  - %s\n", e.Error())
+		}
+	}
+	return valid, nil
+}
+
+// validateApplication defaults and validates the Application held by obj.
+func validateApplication(ctx context.Context, obj *unstructured.Unstructured) (field.ErrorList, error) {
+	app := &workloadv1alpha1.Application{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, app); err != nil {
+		return nil, err
+	}
+	defaulter := &webhookworkloadv1alpha1.ApplicationCustomDefaulter{}
+	if err := defaulter.Default(ctx, app); err != nil {
+		return nil, err
+	}
+	return webhookworkloadv1alpha1.ValidateApplicationSpec(app), nil
+}
+
+// validateWorkspace defaults and validates the Workspace held by obj.
+func validateWorkspace(ctx context.Context, obj *unstructured.Unstructured) (field.ErrorList, error) {
+	workspace := &tenancyv1alpha1.Workspace{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, workspace); err != nil {
+		return nil, err
+	}
+	defaulter := &webhooktenancyv1alpha1.WorkspaceCustomDefaulter{}
+	if err := defaulter.Default(ctx, workspace); err != nil {
+		return nil, err
+	}
+	return webhooktenancyv1alpha1.ValidateWorkspace(workspace), nil
+}
@@ -0,0 +1,14 @@
+package validate
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidate(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Validate Command Suite")
+}
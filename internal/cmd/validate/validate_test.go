@@ -0,0 +1,202 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+const validApplication = `
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: good-app
+  namespace: my-workspace
+spec:
+  containers:
+  - name: app
+    image: nginx:latest
+    ports:
+    - containerPort: 80
+`
+
+const validWorkspace = `
+apiVersion: tenancy.fcp.funccloud.com/v1alpha1
+kind: Workspace
+metadata:
+  name: alice
+spec:
+  type: personal
+  owners:
+  - kind: User
+    name: alice
+`
+
+var _ = Describe("fcp validate", func() {
+	var (
+		ctx     context.Context
+		streams genericiooptions.IOStreams
+		out     *bytes.Buffer
+		dir     string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		dir = GinkgoT().TempDir()
+	})
+
+	writeManifest := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	run := func(filenames ...string) error {
+		o := &Options{Filenames: filenames, IOStreams: streams}
+		Expect(o.Validate()).To(Succeed())
+		return o.Run(ctx)
+	}
+
+	It("should accept a valid application and apply defaults", func() {
+		Expect(run(writeManifest("app.yaml", validApplication))).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("application/good-app is valid"))
+	})
+
+	It("should reject an application without containers", func() {
+		path := writeManifest("app.yaml", `
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: no-containers
+spec: {}
+`)
+		Expect(run(path)).To(MatchError(ErrInvalidManifest))
+		Expect(out.String()).To(ContainSubstring("at least one container is required"))
+	})
+
+	It("should reject containers without an image or ports", func() {
+		path := writeManifest("app.yaml", `
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: no-image
+spec:
+  containers:
+  - name: app
+`)
+		Expect(run(path)).To(MatchError(ErrInvalidManifest))
+		Expect(out.String()).To(ContainSubstring("image is required"))
+		Expect(out.String()).To(ContainSubstring("ports is required"))
+	})
+
+	It("should reject minReplicas greater than maxReplicas", func() {
+		path := writeManifest("app.yaml", `
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: bad-scale
+spec:
+  containers:
+  - name: app
+    image: nginx:latest
+    ports:
+    - containerPort: 80
+  scale:
+    minReplicas: 3
+    maxReplicas: 1
+`)
+		Expect(run(path)).To(MatchError(ErrInvalidManifest))
+		Expect(out.String()).To(ContainSubstring("minReplicas must be less than or equal to maxReplicas"))
+	})
+
+	It("should report every application in a multi-document manifest", func() {
+		path := writeManifest("apps.yaml", validApplication+`
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: workload.fcp.funccloud.com/v1alpha1
+kind: Application
+metadata:
+  name: broken
+spec: {}
+`)
+		Expect(run(path)).To(MatchError(ErrInvalidManifest))
+		Expect(out.String()).To(ContainSubstring("application/good-app is valid"))
+		Expect(out.String()).To(ContainSubstring("application/broken is invalid"))
+	})
+
+	It("should accept a valid workspace", func() {
+		Expect(run(writeManifest("workspace.yaml", validWorkspace))).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("workspace/alice is valid"))
+	})
+
+	DescribeTable("should reject an invalid workspace",
+		func(spec, message string) {
+			path := writeManifest("workspace.yaml", `
+apiVersion: tenancy.fcp.funccloud.com/v1alpha1
+kind: Workspace
+metadata:
+  name: alice
+spec:
+`+spec)
+			Expect(run(path)).To(MatchError(ErrInvalidManifest))
+			Expect(out.String()).To(ContainSubstring("workspace/alice is invalid"))
+			Expect(out.String()).To(ContainSubstring(message))
+		},
+		Entry("without a type", `
+  owners:
+  - kind: User
+    name: alice
+`, "workspaceType is required"),
+		Entry("with an unsupported type", `
+  type: team
+  owners:
+  - kind: User
+    name: alice
+`, "Unsupported value"),
+		Entry("without owners", `
+  type: organization
+`, "owners is required"),
+		Entry("personal with several owners", `
+  type: personal
+  owners:
+  - kind: User
+    name: alice
+  - kind: User
+    name: bob
+`, "must have a single owner for personal workspaces"),
+		Entry("personal owned by a group", `
+  type: personal
+  owners:
+  - kind: Group
+    name: alice
+`, "owner kind must be User for personal workspaces"),
+		Entry("personal owned by another user", `
+  type: personal
+  owners:
+  - kind: User
+    name: bob
+`, "owner name must match workspace name for personal workspaces"),
+	)
+
+	It("should fail on a malformed manifest", func() {
+		path := writeManifest("app.yaml", "kind: [")
+		err := run(path)
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(ErrInvalidManifest))
+	})
+
+	It("should require a filename", func() {
+		o := &Options{IOStreams: streams}
+		Expect(o.Validate()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,200 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	"go.funccloud.dev/fcp/internal/scheme"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/rest"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	rbacLong = templates.LongDesc(i18n.T(`
+		Print the permissions the owners of a workspace are granted.
+
+		The Role and RoleBinding created for the workspace are read and their rules
+		and subjects printed. With --review-as, the API server is also asked which
+		actions that owner may perform in the workspace namespace, through a
+		SelfSubjectRulesReview made while impersonating the owner; this requires
+		permission to impersonate.`))
+
+	rbacExample = templates.Examples(i18n.T(`
+		# Show the rules and subjects of the team-a workspace
+		fcp workspace rbac team-a
+
+		# Also resolve what the owner alice can do in the workspace
+		fcp workspace rbac team-a --review-as alice`))
+)
+
+type RBACOptions struct {
+	Workspace string
+	ReviewAs  string
+	genericiooptions.IOStreams
+	Client client.Client
+
+	// newReviewClient returns a client impersonating an owner for the SelfSubjectRulesReview.
+	newReviewClient func(rest.ImpersonationConfig) (client.Client, error)
+}
+
+func NewCmdWorkspaceRBAC(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := &RBACOptions{IOStreams: ioStreams}
+	cmd := &cobra.Command{
+		Use:     "rbac WORKSPACE",
+		Short:   i18n.T("Print the permissions granted to the owners of a workspace"),
+		Long:    rbacLong,
+		Example: rbacExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+	cmd.Flags().StringVar(&o.ReviewAs, "review-as", "", "Name of a User or ServiceAccount owner to resolve the permissions of")
+	return cmd
+}
+
+func (o *RBACOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	o.Workspace = args[0]
+	cfg, err := f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.Client, err = client.New(cfg, client.Options{
+		Scheme: scheme.Get(),
+	})
+	if err != nil {
+		return err
+	}
+	o.newReviewClient = func(impersonate rest.ImpersonationConfig) (client.Client, error) {
+		reviewCfg := rest.CopyConfig(cfg)
+		reviewCfg.Impersonate = impersonate
+		return client.New(reviewCfg, client.Options{Scheme: scheme.Get()})
+	}
+	return nil
+}
+
+func (o *RBACOptions) Run(ctx context.Context) error {
+	workspace := &tenancyv1alpha1.Workspace{}
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: o.Workspace}, workspace); err != nil {
+		return err
+	}
+	role := &rbacv1.Role{}
+	if err := o.Client.Get(ctx, client.ObjectKey{Namespace: workspace.Name, Name: workspace.Name}, role); err != nil {
+		return fmt.Errorf("reading the role of workspace %s: %w", workspace.Name, err)
+	}
+	binding := &rbacv1.RoleBinding{}
+	if err := o.Client.Get(ctx, client.ObjectKey{Namespace: workspace.Name, Name: workspace.OwnerRoleBindingName()}, binding); err != nil {
+		return fmt.Errorf("reading the role binding of workspace %s: %w", workspace.Name, err)
+	}
+
+	_, _ = fmt.Fprintf(o.Out, "Workspace %s grants Role %s/%s through RoleBinding %s/%s\n\n",
+		workspace.Name, role.Namespace, role.Name, binding.Namespace, binding.Name)
+	w := printers.GetNewTabWriter(o.Out)
+	_, _ = fmt.Fprintln(w, "SUBJECT KIND\tNAME\tNAMESPACE")
+	for _, subject := range binding.Subjects {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", subject.Kind, subject.Name, orNone(subject.Namespace))
+	}
+	_, _ = fmt.Fprintln(w)
+	printRules(w, role.Rules)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if o.ReviewAs == "" {
+		return nil
+	}
+	impersonate, err := ownerImpersonation(workspace, o.ReviewAs)
+	if err != nil {
+		return err
+	}
+	reviewClient, err := o.newReviewClient(impersonate)
+	if err != nil {
+		return err
+	}
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: workspace.Name},
+	}
+	if err := reviewClient.Create(ctx, review); err != nil {
+		return fmt.Errorf("reviewing the permissions of %s: %w", impersonate.UserName, err)
+	}
+	_, _ = fmt.Fprintf(o.Out, "\nPermissions of %s in namespace %s:\n", impersonate.UserName, workspace.Name)
+	w = printers.GetNewTabWriter(o.Out)
+	rules := make([]rbacv1.PolicyRule, 0, len(review.Status.ResourceRules))
+	for _, rule := range review.Status.ResourceRules {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: rule.APIGroups, Resources: rule.Resources, ResourceNames: rule.ResourceNames, Verbs: rule.Verbs,
+		})
+	}
+	printRules(w, rules)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if review.Status.Incomplete {
+		_, _ = fmt.Fprintf(o.ErrOut, "Warning: the permissions may be incomplete: %s\n", review.Status.EvaluationError)
+	}
+	return nil
+}
+
+// ownerImpersonation returns the identity to impersonate for the named workspace owner. Groups
+// cannot be impersonated on their own, so only User and ServiceAccount owners are accepted.
+func ownerImpersonation(workspace *tenancyv1alpha1.Workspace, name string) (rest.ImpersonationConfig, error) {
+	for _, owner := range workspace.Spec.Owners {
+		if owner.Name != name {
+			continue
+		}
+		switch owner.Kind {
+		case rbacv1.UserKind:
+			return rest.ImpersonationConfig{UserName: owner.Name}, nil
+		case rbacv1.ServiceAccountKind:
+			namespace := owner.Namespace
+			if namespace == "" {
+				namespace = workspace.Name
+			}
+			return rest.ImpersonationConfig{UserName: serviceaccount.MakeUsername(namespace, owner.Name)}, nil
+		default:
+			return rest.ImpersonationConfig{}, fmt.Errorf("owner %s is a %s, only User and ServiceAccount owners can be reviewed",
+				name, owner.Kind)
+		}
+	}
+	return rest.ImpersonationConfig{}, fmt.Errorf("%s is not an owner of workspace %s", name, workspace.Name)
+}
+
+func printRules(w io.Writer, rules []rbacv1.PolicyRule) {
+	_, _ = fmt.Fprintln(w, "API GROUPS\tRESOURCES\tRESOURCE NAMES\tVERBS")
+	for _, rule := range rules {
+		apiGroups := make([]string, len(rule.APIGroups))
+		for i, group := range rule.APIGroups {
+			apiGroups[i] = group
+			if group == "" {
+				apiGroups[i] = "core"
+			}
+		}
+		resources := append(append([]string{}, rule.Resources...), rule.NonResourceURLs...)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", join(apiGroups), join(resources), join(rule.ResourceNames),
+			join(rule.Verbs))
+	}
+}
+
+func join(values []string) string {
+	return orNone(strings.Join(values, ","))
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
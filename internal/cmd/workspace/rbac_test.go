@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	tenancycontroller "go.funccloud.dev/fcp/internal/controller/tenancy"
+	"go.funccloud.dev/fcp/internal/scheme"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("fcp workspace rbac", func() {
+	const wsName = "team-a"
+
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		out       *bytes.Buffer
+		o         *RBACOptions
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		workspace := &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: wsName},
+			Spec: tenancyv1alpha1.WorkspaceSpec{
+				Type: tenancyv1alpha1.WorkspaceTypeOrganization,
+				Owners: []corev1.ObjectReference{
+					{Kind: rbacv1.UserKind, Name: "alice"},
+					{Kind: rbacv1.GroupKind, Name: "admins"},
+					{Kind: rbacv1.ServiceAccountKind, Name: "deployer"},
+				},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme.Get()).
+			WithObjects(workspace).WithStatusSubresource(workspace).Build()
+		reconciler := &tenancycontroller.WorkspaceReconciler{Client: k8sClient, Scheme: scheme.Get()}
+		for range 2 {
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(workspace)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		var streams genericiooptions.IOStreams
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		o = &RBACOptions{Workspace: wsName, IOStreams: streams, Client: k8sClient}
+	})
+
+	It("should print the rules and subjects of the workspace Role", func() {
+		Expect(o.Run(ctx)).To(Succeed())
+
+		role := &rbacv1.Role{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: wsName, Name: wsName}, role)).To(Succeed())
+		output := out.String()
+		Expect(output).To(ContainSubstring("RoleBinding team-a/fcp-ownership-team-a"))
+		for _, rule := range role.Rules {
+			Expect(output).To(MatchRegexp(`(?m)^%s\s+%s\s+<none>\s+%s\s*$`, regexpQuote(rule.APIGroups),
+				regexpQuote(rule.Resources), regexpQuote(rule.Verbs)))
+		}
+		Expect(output).To(MatchRegexp(`(?m)^User\s+alice\s+<none>`))
+		Expect(output).To(MatchRegexp(`(?m)^Group\s+admins\s+<none>`))
+		Expect(output).To(MatchRegexp(`(?m)^ServiceAccount\s+deployer\s+team-a`))
+	})
+
+	It("should review the permissions of an owner by impersonating it", func() {
+		var impersonated []string
+		o.ReviewAs = "deployer"
+		o.newReviewClient = func(impersonate rest.ImpersonationConfig) (client.Client, error) {
+			impersonated = append(impersonated, impersonate.UserName)
+			return fake.NewClientBuilder().WithScheme(scheme.Get()).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					review := obj.(*authorizationv1.SelfSubjectRulesReview)
+					Expect(review.Spec.Namespace).To(Equal(wsName))
+					review.Status.ResourceRules = []authorizationv1.ResourceRule{
+						{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+					}
+					return nil
+				},
+			}).Build(), nil
+		}
+
+		Expect(o.Run(ctx)).To(Succeed())
+		Expect(impersonated).To(Equal([]string{"system:serviceaccount:team-a:deployer"}))
+		Expect(out.String()).To(MatchRegexp(`(?m)^core\s+pods\s+<none>\s+get,list\s*$`))
+	})
+
+	It("should refuse to review a Group or an unknown owner", func() {
+		o.ReviewAs = "admins"
+		Expect(o.Run(ctx)).To(MatchError(ContainSubstring("only User and ServiceAccount owners")))
+		o.ReviewAs = "mallory"
+		Expect(o.Run(ctx)).To(MatchError(ContainSubstring("mallory is not an owner of workspace team-a")))
+	})
+})
+
+// regexpQuote joins values the way the rules table does and escapes them for a regular expression.
+func regexpQuote(values []string) string {
+	return regexp.QuoteMeta(strings.Join(values, ","))
+}
@@ -0,0 +1,21 @@
+package workspace
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+func NewCmdWorkspace(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workspace SUBCOMMAND",
+		Aliases: []string{"ws"},
+		Short:   i18n.T("Inspect FCP workspaces"),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.DefaultSubCommandRun(ioStreams.ErrOut)(cmd, args)
+		},
+	}
+	cmd.AddCommand(NewCmdWorkspaceRBAC(f, ioStreams))
+	return cmd
+}
@@ -1,12 +1,123 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/spf13/pflag"
 	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/yaml"
 )
 
+// FileName is the name of the fcp config file in the config directory.
+const FileName = "config.yaml"
+
+// Keys are the settings the config file may hold, as "<command>.<flag>", with their description.
+var Keys = map[string]string{
+	"install.domain": "Default --domain of fcp install",
+	"install.registries-skipping-tag-resolving": "Default --registries-skipping-tag-resolving of fcp install",
+	"install.revision-timeout":                  "Default --revision-timeout of fcp install",
+	"install.knative-feature":                   "Default --knative-feature of fcp install",
+	"install.kind":                              "Default --kind of fcp install",
+}
+
 func GetConfigDir() string {
 	home := homedir.HomeDir()
 	return filepath.Join(home, ".fcp")
 }
+
+// GetConfigFile returns the path of the fcp config file, ~/.fcp/config.yaml.
+func GetConfigFile() string {
+	return filepath.Join(GetConfigDir(), FileName)
+}
+
+// Config holds the persistent flag defaults, keyed by "<command>.<flag>".
+type Config map[string]string
+
+// Load reads the config file at path. A missing file is an empty config.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := Config{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config to path, creating its directory if needed.
+func (c Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Set stores the value of a known key. An empty value removes the key.
+func (c Config) Set(key, value string) error {
+	if _, ok := Keys[key]; !ok {
+		known := make([]string, 0, len(Keys))
+		for k := range Keys {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("unknown config key %q, supported keys: %s", key, strings.Join(known, ", "))
+	}
+	if value == "" {
+		delete(c, key)
+		return nil
+	}
+	c[key] = value
+	return nil
+}
+
+// ApplyFlagDefaults seeds the flags of the command section from the config file, see
+// Config.ApplyFlagDefaults.
+func ApplyFlagDefaults(flags *pflag.FlagSet, section string) error {
+	cfg, err := Load(GetConfigFile())
+	if err != nil {
+		return err
+	}
+	return cfg.ApplyFlagDefaults(flags, section)
+}
+
+// ApplyFlagDefaults sets the flags that were not given on the command line. A flag takes its
+// value from the FCP_<SECTION>_<FLAG> environment variable, e.g. FCP_INSTALL_DOMAIN, or else
+// from the "<section>.<flag>" config key; without either it keeps its built-in default.
+func (c Config) ApplyFlagDefaults(flags *pflag.FlagSet, section string) error {
+	var errs []error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed {
+			return
+		}
+		value, ok := os.LookupEnv(EnvVar(section, flag.Name))
+		if !ok {
+			value, ok = c[section+"."+flag.Name]
+		}
+		if !ok {
+			return
+		}
+		if err := flags.Set(flag.Name, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid default for --%s: %w", flag.Name, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// EnvVar returns the environment variable overriding the config key of a command flag.
+func EnvVar(section, flag string) string {
+	return "FCP_" + strings.ToUpper(strings.ReplaceAll(section+"_"+flag, "-", "_"))
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Config", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), ".fcp", FileName)
+	})
+
+	It("should round trip the stored keys", func() {
+		cfg, err := Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(BeEmpty())
+
+		Expect(cfg.Set("install.domain", "apps.example.com")).To(Succeed())
+		Expect(cfg.Save(path)).To(Succeed())
+		loaded, err := Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(Config{"install.domain": "apps.example.com"}))
+
+		By("removing a key set to an empty value")
+		Expect(loaded.Set("install.domain", "")).To(Succeed())
+		Expect(loaded).To(BeEmpty())
+	})
+
+	It("should reject unknown keys and invalid files", func() {
+		Expect(Config{}.Set("install.domian", "x")).To(MatchError(ContainSubstring("supported keys: install.domain")))
+
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("- not a map"), 0o600)).To(Succeed())
+		_, err := Load(path)
+		Expect(err).To(MatchError(ContainSubstring("invalid config file")))
+	})
+
+	Describe("ApplyFlagDefaults", func() {
+		var (
+			flags  *pflag.FlagSet
+			domain string
+			quiet  bool
+			cfg    Config
+		)
+
+		BeforeEach(func() {
+			flags = pflag.NewFlagSet("install", pflag.ContinueOnError)
+			flags.StringVar(&domain, "domain", "built-in.example.com", "")
+			flags.BoolVar(&quiet, "quiet", false, "")
+			cfg = Config{"install.domain": "config.example.com", "install.quiet": "true"}
+		})
+
+		It("should use the config when neither a flag nor the environment is set", func() {
+			Expect(flags.Parse(nil)).To(Succeed())
+			Expect(cfg.ApplyFlagDefaults(flags, "install")).To(Succeed())
+			Expect(domain).To(Equal("config.example.com"))
+			Expect(quiet).To(BeTrue())
+		})
+
+		It("should prefer the environment over the config", func() {
+			GinkgoT().Setenv(EnvVar("install", "domain"), "env.example.com")
+			Expect(flags.Parse(nil)).To(Succeed())
+			Expect(cfg.ApplyFlagDefaults(flags, "install")).To(Succeed())
+			Expect(domain).To(Equal("env.example.com"))
+		})
+
+		It("should prefer the flag over the environment", func() {
+			GinkgoT().Setenv("FCP_INSTALL_DOMAIN", "env.example.com")
+			Expect(flags.Parse([]string{"--domain", "flag.example.com"})).To(Succeed())
+			Expect(cfg.ApplyFlagDefaults(flags, "install")).To(Succeed())
+			Expect(domain).To(Equal("flag.example.com"))
+		})
+
+		It("should keep the built-in default without any override", func() {
+			Expect(flags.Parse(nil)).To(Succeed())
+			Expect(Config{}.ApplyFlagDefaults(flags, "install")).To(Succeed())
+			Expect(domain).To(Equal("built-in.example.com"))
+		})
+
+		It("should report values the flag cannot parse", func() {
+			Expect(flags.Parse(nil)).To(Succeed())
+			err := Config{"install.quiet": "maybe"}.ApplyFlagDefaults(flags, "install")
+			Expect(err).To(MatchError(ContainSubstring("invalid default for --quiet")))
+		})
+	})
+})
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cleanup deletes the resources a controller created for an object once they are no longer wanted.
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Selector picks the resources considered for cleanup.
+type Selector struct {
+	// Namespace holds the resources.
+	Namespace string
+	// LabelKey and LabelValue are the label the controller sets on the resources it creates.
+	LabelKey   string
+	LabelValue string
+	// Keep, when set, spares the resources that are still wanted.
+	Keep func(client.Object) bool
+	// Orphans also deletes the labelled resources without any controller, as left behind once an
+	// orphan deletion of the owner stripped the owner references of its dependents.
+	Orphans bool
+	// Unlabel removes the label from the resources controlled by something else, so they are no
+	// longer selected for the owner.
+	Unlabel bool
+}
+
+// OwnedResources deletes the resources of the given list types matching the selector and controlled
+// by owner. Resources carrying the label but controlled by something else are never deleted, and
+// neither are those controlled by nothing unless the selector asks for orphans. Listing and deletion
+// failures are aggregated so one failing type does not stop the others.
+func OwnedResources(
+	ctx context.Context,
+	c client.Client,
+	owner client.Object,
+	selector Selector,
+	lists ...client.ObjectList,
+) error {
+	l := logf.FromContext(ctx)
+	var errs []error
+	for _, list := range lists {
+		kind := kindOf(c.Scheme(), list)
+		if err := c.List(ctx, list, client.InNamespace(selector.Namespace),
+			client.MatchingLabels{selector.LabelKey: selector.LabelValue}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %ss for cleanup: %w", kind, err))
+			continue
+		}
+		err := meta.EachListItem(list, func(item runtime.Object) error {
+			obj := item.(client.Object)
+			controller := metav1.GetControllerOf(obj)
+			orphan := controller == nil && selector.Orphans
+			if !orphan && !metav1.IsControlledBy(obj, owner) {
+				if controller != nil && selector.Unlabel {
+					l.Info("Removing the owner label from a resource controlled elsewhere",
+						"kind", kind, "name", obj.GetName(), "controller", controller.Name)
+					patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+					labels := obj.GetLabels()
+					delete(labels, selector.LabelKey)
+					obj.SetLabels(labels)
+					if err := c.Patch(ctx, obj, patch); err != nil && !apierrors.IsNotFound(err) {
+						errs = append(errs, fmt.Errorf("failed to unlabel %s %s: %w", kind, obj.GetName(), err))
+					}
+					return nil
+				}
+				l.V(1).Info("Found resource with the owner label but a different/no owner, skipping deletion.",
+					"kind", kind, "name", obj.GetName())
+				return nil
+			}
+			if selector.Keep != nil && selector.Keep(obj) {
+				return nil
+			}
+			l.Info("Deleting owned resource", "kind", kind, "name", obj.GetName())
+			if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to delete %s %s: %w", kind, obj.GetName(), err))
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// kindOf returns the kind of the items of list, falling back to its Go type.
+func kindOf(scheme *runtime.Scheme, list client.ObjectList) string {
+	gvk, err := apiutil.GVKForObject(list, scheme)
+	if err != nil {
+		return fmt.Sprintf("%T", list)
+	}
+	return strings.TrimSuffix(gvk.Kind, "List")
+}
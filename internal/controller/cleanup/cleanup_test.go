@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/scheme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	namespace = "cleanup-ns"
+	labelKey  = "example.com/owner"
+)
+
+var _ = Describe("OwnedResources", func() {
+	var (
+		ctx      context.Context
+		owner    *corev1.ConfigMap
+		selector Selector
+	)
+
+	controlledBy := func(ownerName string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: "v1", Kind: "ConfigMap", Name: ownerName, UID: types.UID("uid-" + ownerName), Controller: ptr.To(true),
+		}}
+	}
+
+	secret := func(name string, labelled bool, owners []metav1.OwnerReference) *corev1.Secret {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, OwnerReferences: owners}}
+		if labelled {
+			s.Labels = map[string]string{labelKey: "owner"}
+		}
+		return s
+	}
+
+	exists := func(c client.Client, name string) bool {
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &corev1.Secret{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		owner = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: namespace, UID: "uid-owner"}}
+		selector = Selector{Namespace: namespace, LabelKey: labelKey, LabelValue: "owner"}
+	})
+
+	It("should only delete the labelled resources controlled by the owner", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(
+			secret("owned", true, controlledBy("owner")),
+			secret("unowned", true, nil),
+			secret("foreign", true, controlledBy("other")),
+			secret("unlabelled", false, controlledBy("owner")),
+		).Build()
+
+		Expect(OwnedResources(ctx, c, owner, selector, &corev1.SecretList{})).To(Succeed())
+		Expect(exists(c, "owned")).To(BeFalse())
+		Expect(exists(c, "unowned")).To(BeTrue())
+		Expect(exists(c, "foreign")).To(BeTrue())
+		Expect(exists(c, "unlabelled")).To(BeTrue())
+	})
+
+	It("should delete orphans and unlabel foreign resources when asked to", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(
+			secret("owned", true, controlledBy("owner")),
+			secret("orphaned", true, nil),
+			secret("foreign", true, controlledBy("other")),
+		).Build()
+		selector.Orphans = true
+		selector.Unlabel = true
+
+		Expect(OwnedResources(ctx, c, owner, selector, &corev1.SecretList{})).To(Succeed())
+		Expect(exists(c, "owned")).To(BeFalse())
+		Expect(exists(c, "orphaned")).To(BeFalse())
+		foreign := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "foreign"}, foreign)).To(Succeed())
+		Expect(foreign.Labels).NotTo(HaveKey(labelKey))
+	})
+
+	It("should keep the resources still wanted", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(
+			secret("wanted", true, controlledBy("owner")),
+			secret("stale", true, controlledBy("owner")),
+		).Build()
+		selector.Keep = func(obj client.Object) bool { return obj.GetName() == "wanted" }
+
+		Expect(OwnedResources(ctx, c, owner, selector, &corev1.SecretList{})).To(Succeed())
+		Expect(exists(c, "wanted")).To(BeTrue())
+		Expect(exists(c, "stale")).To(BeFalse())
+	})
+
+	It("should aggregate deletion failures and keep going", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(
+			secret("stuck", true, controlledBy("owner")),
+			secret("owned", true, controlledBy("owner")),
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if obj.GetName() == "stuck" {
+					return errors.New("injected failure")
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).Build()
+
+		err := OwnedResources(ctx, c, owner, selector, &corev1.SecretList{}, &corev1.ConfigMapList{})
+		Expect(err).To(MatchError(ContainSubstring("failed to delete Secret stuck: injected failure")))
+		Expect(exists(c, "stuck")).To(BeTrue())
+		Expect(exists(c, "owned")).To(BeFalse())
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes how busy the fcp controllers are, so operators can alert when
+// reconciliation falls behind. Workqueue depth and reconcile latency are already exported by
+// controller-runtime as workqueue_depth and controller_runtime_reconcile_time_seconds.
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	// ReconcilesInFlight is the number of reconciles currently running per controller.
+	ReconcilesInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fcp_controller_reconciles_in_flight",
+		Help: "Number of reconciles currently running per fcp controller.",
+	}, []string{"controller"})
+	// Saturation is the share of the reconcile workers of a controller that are busy. A value
+	// stuck at 1 means events queue up faster than they are reconciled.
+	Saturation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fcp_controller_saturation",
+		Help: "Share of the reconcile workers of the fcp controller that are busy, from 0 to 1.",
+	}, []string{"controller"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ReconcilesInFlight, Saturation)
+}
+
+// Instrument wraps the reconciler of the named controller, running at most workers reconciles at
+// once, so that its in-flight reconciles and saturation are reported.
+func Instrument(controller string, workers int, r reconcile.Reconciler) reconcile.Reconciler {
+	inFlight := ReconcilesInFlight.WithLabelValues(controller)
+	saturation := Saturation.WithLabelValues(controller)
+	workers = max(workers, 1)
+	var mu sync.Mutex
+	running := 0
+	track := func(delta int) {
+		mu.Lock()
+		defer mu.Unlock()
+		running += delta
+		inFlight.Set(float64(running))
+		saturation.Set(float64(running) / float64(workers))
+	}
+	track(0)
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		track(1)
+		defer track(-1)
+		return r.Reconcile(ctx, req)
+	})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Instrument", func() {
+	It("should report the busy workers during a reconcile burst", func() {
+		const controller, workers = "test-burst", 4
+		release := make(chan struct{})
+		started := make(chan struct{}, workers)
+		r := Instrument(controller, workers, reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			started <- struct{}{}
+			<-release
+			return reconcile.Result{}, nil
+		}))
+		Expect(testutil.ToFloat64(Saturation.WithLabelValues(controller))).To(BeZero())
+
+		var wg sync.WaitGroup
+		for range workers - 1 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+			}()
+		}
+		for range workers - 1 {
+			Eventually(started).Should(Receive())
+		}
+		Expect(testutil.ToFloat64(ReconcilesInFlight.WithLabelValues(controller))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(Saturation.WithLabelValues(controller))).To(Equal(0.75))
+
+		close(release)
+		wg.Wait()
+		Expect(testutil.ToFloat64(ReconcilesInFlight.WithLabelValues(controller))).To(BeZero())
+		Expect(testutil.ToFloat64(Saturation.WithLabelValues(controller))).To(BeZero())
+	})
+})
@@ -19,9 +19,14 @@ package tenancy
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/controller/cleanup"
+	controllermetrics "go.funccloud.dev/fcp/internal/controller/metrics"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -29,10 +34,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -113,8 +122,7 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(workspace, tenancyv1alpha1.WorkspaceFinalizer) {
 		l.Info("Adding finalizer")
-		controllerutil.AddFinalizer(workspace, tenancyv1alpha1.WorkspaceFinalizer)
-		if err := r.Update(ctx, workspace); err != nil {
+		if err := r.updateFinalizer(ctx, workspace, controllerutil.AddFinalizer); err != nil {
 			l.Error(err, "unable to add finalizer")
 			return ctrl.Result{}, err
 		}
@@ -127,19 +135,39 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		workspace.Status.SetCondition(metav1.Condition{
 			Type:    tenancyv1alpha1.ReadyConditionType,
 			Status:  metav1.ConditionFalse,
-			Reason:  "ReconciliationFailed",
+			Reason:  tenancyv1alpha1.ReconciliationFailedReason,
 			Message: fmt.Sprintf("Failed to reconcile resources: %v", err),
 		})
 		return ctrl.Result{}, err // Return error to requeue
 	}
 
+	// Propagate the suspend state to the Applications of the workspace
+	if err = r.reconcileSuspension(ctx, l, workspace); err != nil {
+		workspace.Status.SetCondition(metav1.Condition{
+			Type:    tenancyv1alpha1.ReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  tenancyv1alpha1.SuspensionFailedReason,
+			Message: fmt.Sprintf("Failed to update applications: %v", err),
+		})
+		return ctrl.Result{}, err
+	}
+
 	// Update status to Ready
-	workspace.Status.SetCondition(metav1.Condition{
-		Type:    tenancyv1alpha1.ReadyConditionType,
-		Status:  metav1.ConditionTrue,
-		Reason:  tenancyv1alpha1.ResourcesCreatedReason,
-		Message: fmt.Sprintf("Workspace %s is ready", workspace.Name),
-	})
+	if workspace.Spec.Suspended {
+		workspace.Status.SetCondition(metav1.Condition{
+			Type:    tenancyv1alpha1.ReadyConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  tenancyv1alpha1.WorkspaceSuspendedReason,
+			Message: fmt.Sprintf("Workspace %s is suspended", workspace.Name),
+		})
+	} else {
+		workspace.Status.SetCondition(metav1.Condition{
+			Type:    tenancyv1alpha1.ReadyConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  tenancyv1alpha1.ResourcesCreatedReason,
+			Message: fmt.Sprintf("Workspace %s is ready", workspace.Name),
+		})
+	}
 	workspace.Status.ObservedGeneration = workspace.Generation
 	l.Info("Workspace reconciled successfully")
 	return ctrl.Result{}, nil
@@ -188,10 +216,9 @@ func (r *WorkspaceReconciler) reconcileResources(ctx context.Context, l logr.Log
 	}
 
 	// Reconcile RoleBinding
-	roleBindingName := fmt.Sprintf("fcp-ownership-%s", workspace.Name)
 	roleBinding := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      roleBindingName,
+			Name:      workspace.OwnerRoleBindingName(),
 			Namespace: workspace.Name,
 		},
 	}
@@ -202,26 +229,9 @@ func (r *WorkspaceReconciler) reconcileResources(ctx context.Context, l logr.Log
 			Kind:     "Role",
 			Name:     role.Name,
 		}
-		subjects := []rbacv1.Subject{}
-		for _, owner := range workspace.Spec.Owners {
-			// Ensure Group is set correctly for core K8s kinds like User/Group/ServiceAccount
-			apiGroup := owner.APIVersion // Use APIVersion from ObjectReference first
-			if apiGroup == "" {          // If APIVersion is empty, infer based on Kind
-				if owner.Kind == "User" || owner.Kind == "Group" {
-					apiGroup = rbacv1.GroupName // Explicitly set for core RBAC kinds
-				} else if owner.Kind == "ServiceAccount" {
-					apiGroup = "" // Core API group
-				}
-				// Add more Kind checks if needed
-			}
-
-			subjects = append(subjects, rbacv1.Subject{
-				APIGroup: apiGroup,
-				Kind:     owner.Kind,
-				Name:     owner.Name,
-			})
-		}
-		roleBinding.Subjects = subjects
+		// Subjects are rebuilt from the owners on every reconcile, so removed owners lose access
+		// as soon as the Workspace change is reconciled.
+		roleBinding.Subjects = ownerSubjects(workspace)
 		return nil
 	}); err != nil {
 		workspace.Status.SetCondition(metav1.Condition{
@@ -240,9 +250,116 @@ func (r *WorkspaceReconciler) reconcileResources(ctx context.Context, l logr.Log
 		Message: fmt.Sprintf("Role and RoleBinding created/updated in namespace %s", ns.Name),
 	})
 
+	if err := r.reconcileImagePullSecrets(ctx, l, workspace); err != nil {
+		return fmt.Errorf("failed to reconcile image pull secrets: %w", err)
+	}
 	return nil
 }
 
+// reconcileImagePullSecrets adds the workspace ImagePullSecrets to the default ServiceAccount of the
+// namespace, creating it if the namespace is too new to have one yet. Secrets added by hand are kept,
+// only those the workspace added before and no longer lists are removed.
+func (r *WorkspaceReconciler) reconcileImagePullSecrets(ctx context.Context, l logr.Logger,
+	workspace *tenancyv1alpha1.Workspace) error {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: workspace.Name}}
+	if len(workspace.Spec.ImagePullSecrets) == 0 {
+		// Nothing to add, so leave creating the ServiceAccount to Kubernetes.
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sa), sa); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+	}
+	opRes, err := controllerutil.CreateOrPatch(ctx, r.Client, sa, func() error {
+		wanted := sets.New[string]()
+		for _, secret := range workspace.Spec.ImagePullSecrets {
+			wanted.Insert(secret.Name)
+		}
+		previous := sets.New[string]()
+		if managed := sa.Annotations[tenancyv1alpha1.WorkspaceImagePullSecretsAnnotation]; managed != "" {
+			previous.Insert(strings.Split(managed, ",")...)
+		}
+		sa.ImagePullSecrets = slices.DeleteFunc(sa.ImagePullSecrets, func(ref corev1.LocalObjectReference) bool {
+			return previous.Has(ref.Name) && !wanted.Has(ref.Name)
+		})
+		for _, secret := range workspace.Spec.ImagePullSecrets {
+			if !slices.Contains(sa.ImagePullSecrets, secret) {
+				sa.ImagePullSecrets = append(sa.ImagePullSecrets, secret)
+			}
+		}
+		if wanted.Len() == 0 {
+			delete(sa.Annotations, tenancyv1alpha1.WorkspaceImagePullSecretsAnnotation)
+			return nil
+		}
+		if sa.Annotations == nil {
+			sa.Annotations = make(map[string]string)
+		}
+		sa.Annotations[tenancyv1alpha1.WorkspaceImagePullSecretsAnnotation] = strings.Join(sets.List(wanted), ",")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if opRes != controllerutil.OperationResultNone {
+		l.Info("Default ServiceAccount image pull secrets updated", "operation", opRes)
+	}
+	return nil
+}
+
+// reconcileSuspension labels every Application of the workspace while it is suspended and removes
+// the label once it is resumed, so suspended Applications can be selected. The label is informational:
+// the Application controller scales Applications to zero from the Workspace itself.
+func (r *WorkspaceReconciler) reconcileSuspension(ctx context.Context, l logr.Logger,
+	workspace *tenancyv1alpha1.Workspace) error {
+	apps := &workloadv1alpha1.ApplicationList{}
+	if err := r.List(ctx, apps, client.InNamespace(workspace.Name)); err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		_, labelled := app.Labels[tenancyv1alpha1.WorkspaceSuspendedLabel]
+		if labelled == workspace.Spec.Suspended {
+			continue
+		}
+		patch := client.MergeFrom(app.DeepCopy())
+		if workspace.Spec.Suspended {
+			if app.Labels == nil {
+				app.Labels = make(map[string]string)
+			}
+			app.Labels[tenancyv1alpha1.WorkspaceSuspendedLabel] = "true"
+		} else {
+			delete(app.Labels, tenancyv1alpha1.WorkspaceSuspendedLabel)
+		}
+		if err := r.Patch(ctx, app, patch); err != nil {
+			return fmt.Errorf("failed to update application %s: %w", app.Name, err)
+		}
+		l.Info("Application suspend state updated", "application", app.Name, "suspended", workspace.Spec.Suspended)
+	}
+	return nil
+}
+
+// ownerSubjects returns the RoleBinding subjects granting the workspace owners access.
+func ownerSubjects(workspace *tenancyv1alpha1.Workspace) []rbacv1.Subject {
+	subjects := []rbacv1.Subject{}
+	for _, owner := range workspace.Spec.Owners {
+		subject := rbacv1.Subject{
+			APIGroup: owner.APIVersion, // Use APIVersion from ObjectReference first
+			Kind:     owner.Kind,
+			Name:     owner.Name,
+		}
+		if subject.APIGroup == "" && (owner.Kind == rbacv1.UserKind || owner.Kind == rbacv1.GroupKind) {
+			subject.APIGroup = rbacv1.GroupName // Explicitly set for core RBAC kinds
+		}
+		// ServiceAccount subjects are invalid without a namespace, default to the workspace's own.
+		if owner.Kind == rbacv1.ServiceAccountKind {
+			subject.Namespace = owner.Namespace
+			if subject.Namespace == "" {
+				subject.Namespace = workspace.Name
+			}
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
 // reconcileOwnedResource handles the CreateOrUpdate logic for an owned resource.
 // Remove the unused ownerRef parameter.
 func (r *WorkspaceReconciler) reconcileOwnedResource(
@@ -294,14 +411,20 @@ func (r *WorkspaceReconciler) reconcileDeletion(ctx context.Context, l logr.Logg
 	workspace *tenancyv1alpha1.Workspace) error {
 	l.Info("Reconciling Workspace deletion")
 
-	// Resources (Namespace, Role, RoleBinding) should be garbage collected automatically
-	// by Kubernetes because we set the OwnerReference with Controller=true.
-	// We just need to remove the finalizer.
-
+	// The Namespace is garbage collected through its OwnerReference. The Role and RoleBinding are
+	// deleted right away so the owners lose access even while the Namespace is terminating, or
+	// when the Workspace was deleted with the orphan propagation policy.
 	if controllerutil.ContainsFinalizer(workspace, tenancyv1alpha1.WorkspaceFinalizer) {
+		if err := cleanup.OwnedResources(log.IntoContext(ctx, l), r.Client, workspace, cleanup.Selector{
+			Namespace:  workspace.Name,
+			LabelKey:   tenancyv1alpha1.WorkspaceLinkedResourceLabel,
+			LabelValue: workspace.Name,
+		}, &rbacv1.RoleBindingList{}, &rbacv1.RoleList{}); err != nil {
+			l.Error(err, "unable to delete the workspace RBAC")
+			return err
+		}
 		l.Info("Removing finalizer")
-		controllerutil.RemoveFinalizer(workspace, tenancyv1alpha1.WorkspaceFinalizer)
-		if err := r.Update(ctx, workspace); err != nil {
+		if err := r.updateFinalizer(ctx, workspace, controllerutil.RemoveFinalizer); client.IgnoreNotFound(err) != nil {
 			l.Error(err, "unable to remove finalizer")
 			return err // Return only the error
 		}
@@ -312,18 +435,47 @@ func (r *WorkspaceReconciler) reconcileDeletion(ctx context.Context, l logr.Logg
 	return nil // Return nil error on success
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Define a predicate to filter resources based on the workspace label.
-	workspaceLabelPredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
-		labels := obj.GetLabels()
-		if labels == nil {
-			return false
+// updateFinalizer adds or removes the Workspace finalizer with change, re-fetching the
+// Workspace and retrying when the update conflicts with a concurrent change.
+func (r *WorkspaceReconciler) updateFinalizer(
+	ctx context.Context,
+	workspace *tenancyv1alpha1.Workspace,
+	change func(client.Object, string) bool,
+) error {
+	refetch := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(workspace), workspace); err != nil {
+				return err
+			}
+		}
+		refetch = true
+		if !change(workspace, tenancyv1alpha1.WorkspaceFinalizer) {
+			return nil
 		}
-		_, exists := labels[tenancyv1alpha1.WorkspaceLinkedResourceLabel]
-		return exists
+		return r.Update(ctx, workspace)
 	})
+}
+
+// linkedResourcePredicate selects the resources carrying the workspace label. An update removing
+// the label is selected too, so that the next reconcile restores it right away.
+var linkedResourcePredicate = predicate.Funcs{
+	CreateFunc:  func(e event.CreateEvent) bool { return isWorkspaceLinked(e.Object) },
+	DeleteFunc:  func(e event.DeleteEvent) bool { return isWorkspaceLinked(e.Object) },
+	GenericFunc: func(e event.GenericEvent) bool { return isWorkspaceLinked(e.Object) },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return isWorkspaceLinked(e.ObjectOld) || isWorkspaceLinked(e.ObjectNew)
+	},
+}
 
+func isWorkspaceLinked(obj client.Object) bool {
+	_, exists := obj.GetLabels()[tenancyv1alpha1.WorkspaceLinkedResourceLabel]
+	return exists
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	workers := max(mgr.GetControllerOptions().MaxConcurrentReconciles, 1)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tenancyv1alpha1.Workspace{}).
 		// Use Owns with a predicate to watch resources specifically linked to a Workspace
@@ -331,9 +483,10 @@ func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// labeled resources change unexpectedly (e.g., manual modification or deletion outside GC).
 		// Note: OwnerReferences with Controller=true already trigger reconciliation on deletion.
 		// Owns() primarily helps if the owned object is modified or deleted in a way that bypasses GC.
-		Owns(&corev1.Namespace{}, builder.WithPredicates(workspaceLabelPredicate)).
-		Owns(&rbacv1.Role{}, builder.WithPredicates(workspaceLabelPredicate)).
-		Owns(&rbacv1.RoleBinding{}, builder.WithPredicates(workspaceLabelPredicate)).
+		Owns(&corev1.Namespace{}, builder.WithPredicates(linkedResourcePredicate)).
+		Owns(&rbacv1.Role{}, builder.WithPredicates(linkedResourcePredicate)).
+		Owns(&rbacv1.RoleBinding{}, builder.WithPredicates(linkedResourcePredicate)).
 		Named("tenancy-workspace").
-		Complete(r)
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		Complete(controllermetrics.Instrument("tenancy-workspace", workers, r))
 }
@@ -17,17 +17,24 @@ limitations under the License.
 package tenancy
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -125,4 +132,403 @@ var _ = Describe("Workspace Controller", func() {
 			}, time.Minute, 10*time.Second).Should(Succeed())
 		})
 	})
+	Context("When changing the workspace owners", func() {
+		const wsName = "owners-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+		bindingKey := types.NamespacedName{Name: "fcp-ownership-" + wsName, Namespace: wsName}
+		var controllerReconciler *WorkspaceReconciler
+
+		BeforeEach(func() {
+			controllerReconciler = &WorkspaceReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type: tenancyv1alpha1.WorkspaceTypeOrganization,
+					Owners: []corev1.ObjectReference{
+						{Kind: "User", Name: "alice"},
+						{Kind: "User", Name: "bob"},
+						{Kind: "ServiceAccount", Name: "deployer"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should revoke a removed owner in the next reconcile", func() {
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, bindingKey, roleBinding)).To(Succeed())
+			Expect(roleBinding.Subjects).To(ConsistOf(
+				rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: "User", Name: "alice"},
+				rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: "User", Name: "bob"},
+				rbacv1.Subject{Kind: "ServiceAccount", Name: "deployer", Namespace: wsName},
+			))
+
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			workspace.Spec.Owners = []corev1.ObjectReference{{Kind: "User", Name: "alice"}}
+			Expect(k8sClient.Update(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, bindingKey, roleBinding)).To(Succeed())
+			Expect(roleBinding.Subjects).To(Equal([]rbacv1.Subject{
+				{APIGroup: rbacv1.GroupName, Kind: "User", Name: "alice"},
+			}))
+		})
+	})
+
+	Context("When suspending a workspace", func() {
+		const wsName = "suspend-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+		appKey := types.NamespacedName{Name: "suspend-app", Namespace: wsName}
+		var controllerReconciler *WorkspaceReconciler
+
+		setSuspended := func(suspended bool) {
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			workspace.Spec.Suspended = suspended
+			Expect(k8sClient.Update(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &WorkspaceReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: "test-user"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: appKey.Name, Namespace: appKey.Namespace},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{Image: "nginx:latest"}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			app := &workloadv1alpha1.Application{}
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, app)).To(Succeed())
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should label the applications while suspended and clear the label on resume", func() {
+			By("suspending the workspace")
+			setSuspended(true)
+			app := &workloadv1alpha1.Application{}
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(app.Labels).To(HaveKeyWithValue(tenancyv1alpha1.WorkspaceSuspendedLabel, "true"))
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			cond := workspace.Status.GetCondition(tenancyv1alpha1.ReadyConditionType)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal(tenancyv1alpha1.WorkspaceSuspendedReason))
+
+			By("resuming the workspace")
+			setSuspended(false)
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(app.Labels).NotTo(HaveKey(tenancyv1alpha1.WorkspaceSuspendedLabel))
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			cond = workspace.Status.GetCondition(tenancyv1alpha1.ReadyConditionType)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal(tenancyv1alpha1.ResourcesCreatedReason))
+		})
+	})
+
+	Context("When the workspace lists image pull secrets", func() {
+		const wsName = "pull-secrets-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+		saKey := types.NamespacedName{Name: "default", Namespace: wsName}
+		var controllerReconciler *WorkspaceReconciler
+
+		setImagePullSecrets := func(names ...string) {
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			workspace.Spec.ImagePullSecrets = nil
+			for _, name := range names {
+				workspace.Spec.ImagePullSecrets = append(workspace.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+			}
+			Expect(k8sClient.Update(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &WorkspaceReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:             tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners:           []corev1.ObjectReference{{Kind: "User", Name: "test-user"}},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should keep the default ServiceAccount in sync while preserving secrets added by hand", func() {
+			sa := &corev1.ServiceAccount{}
+			Expect(k8sClient.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "registry"}}))
+			Expect(sa.Annotations).To(HaveKeyWithValue(tenancyv1alpha1.WorkspaceImagePullSecretsAnnotation, "registry"))
+
+			By("adding a secret by hand and replacing the workspace ones")
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: "manual"})
+			Expect(k8sClient.Update(ctx, sa)).To(Succeed())
+			setImagePullSecrets("mirror")
+			Expect(k8sClient.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "manual"}, {Name: "mirror"}}))
+
+			By("removing every workspace secret")
+			setImagePullSecrets()
+			Expect(k8sClient.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "manual"}}))
+			Expect(sa.Annotations).NotTo(HaveKey(tenancyv1alpha1.WorkspaceImagePullSecretsAnnotation))
+		})
+	})
+
+	Context("When the workspace label is removed from an owned resource", func() {
+		const wsName = "labels-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+		var controllerReconciler *WorkspaceReconciler
+
+		BeforeEach(func() {
+			controllerReconciler = &WorkspaceReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: "test-user"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should watch the change and restore the label", func() {
+			owned := []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: wsName}},
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: wsName, Namespace: wsName}},
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "fcp-ownership-" + wsName, Namespace: wsName}},
+			}
+			for _, obj := range owned {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+				old := obj.DeepCopyObject().(client.Object)
+				labels := obj.GetLabels()
+				delete(labels, tenancyv1alpha1.WorkspaceLinkedResourceLabel)
+				obj.SetLabels(labels)
+				Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+				Expect(linkedResourcePredicate.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: obj})).To(BeTrue())
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+			for _, obj := range owned {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+				Expect(obj.GetLabels()).To(HaveKeyWithValue(tenancyv1alpha1.WorkspaceLinkedResourceLabel, wsName))
+			}
+		})
+
+		It("should ignore resources that never carried the label", func() {
+			unlinked := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlinked"}}
+			Expect(linkedResourcePredicate.Create(event.CreateEvent{Object: unlinked})).To(BeFalse())
+			Expect(linkedResourcePredicate.Update(event.UpdateEvent{ObjectOld: unlinked, ObjectNew: unlinked})).To(BeFalse())
+		})
+	})
+
+	Context("When the workspace resources cannot be reconciled", func() {
+		const wsName = "failing-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+
+		AfterEach(func() {
+			workspace := &tenancyv1alpha1.Workspace{}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			controllerReconciler := &WorkspaceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should set the ReconciliationFailed reason on the Ready condition", func() {
+			watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			controllerReconciler := &WorkspaceReconciler{
+				Client: interceptor.NewClient(watchClient, interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if _, ok := obj.(*rbacv1.Role); ok {
+							return fmt.Errorf("injected failure")
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				}),
+				Scheme: k8sClient.Scheme(),
+			}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypeOrganization,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: "test-user"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+
+			for range 2 {
+				_, _ = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			}
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			cond := workspace.Status.GetCondition(tenancyv1alpha1.ReadyConditionType)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(tenancyv1alpha1.ReconciliationFailedReason))
+			Expect(cond.Message).To(ContainSubstring("injected failure"))
+		})
+	})
+
+	Context("When deleting a workspace", func() {
+		const wsName = "deleting-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+
+		It("should delete the workspace RBAC before removing the finalizer", func() {
+			controllerReconciler := &WorkspaceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypeOrganization,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: "test-user"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			roleKey := types.NamespacedName{Namespace: wsName, Name: wsName}
+			bindingKey := types.NamespacedName{Namespace: wsName, Name: workspace.OwnerRoleBindingName()}
+			Expect(k8sClient.Get(ctx, roleKey, &rbacv1.Role{})).To(Succeed())
+			Expect(k8sClient.Get(ctx, bindingKey, &rbacv1.RoleBinding{})).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, roleKey, &rbacv1.Role{}))).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, bindingKey, &rbacv1.RoleBinding{}))).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, wsKey, workspace))).To(BeTrue())
+		})
+	})
+
+	Context("When a finalizer update conflicts with a concurrent change", func() {
+		const wsName = "conflict-ws"
+		wsKey := types.NamespacedName{Name: wsName}
+
+		It("should retry adding and removing the finalizer", func() {
+			watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			conflict := true
+			conflicted := 0
+			controllerReconciler := &WorkspaceReconciler{
+				Client: interceptor.NewClient(watchClient, interceptor.Funcs{
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if conflict {
+							conflict = false
+							conflicted++
+							return errors.NewConflict(schema.GroupResource{Group: tenancyv1alpha1.GroupVersion.Group, Resource: "workspaces"},
+								obj.GetName(), fmt.Errorf("injected conflict"))
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				}),
+				Scheme: k8sClient.Scheme(),
+			}
+			workspace := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: "test-user"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+
+			By("adding the finalizer")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, wsKey, workspace)).To(Succeed())
+			Expect(workspace.Finalizers).To(ContainElement(tenancyv1alpha1.WorkspaceFinalizer))
+
+			By("removing the finalizer")
+			conflict = true
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: wsKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, wsKey, workspace))).To(BeTrue())
+			Expect(conflicted).To(Equal(2))
+		})
+	})
 })
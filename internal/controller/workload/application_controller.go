@@ -17,39 +17,89 @@ limitations under the License.
 package workload
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
 	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/controller/cleanup"
+	controllermetrics "go.funccloud.dev/fcp/internal/controller/metrics"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"knative.dev/networking/pkg/apis/networking"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmp"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/serving"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	servingnetworking "knative.dev/serving/pkg/networking"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil" // Ensure controllerutil is imported
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	prometheusScrapeAnnotation = "prometheus.io/scrape"
+	prometheusPortAnnotation   = "prometheus.io/port"
+	prometheusPathAnnotation   = "prometheus.io/path"
+
+	// revisionVariantLength is the length of the revision name suffix derived from the template
+	// inputs that do not bump the Application generation.
+	revisionVariantLength = 8
+)
+
+// serviceMonitorGVK identifies the Prometheus Operator ServiceMonitor, handled as unstructured
+// since the CRD is optional.
+var serviceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+// errAdoptionRefused is returned when a Knative Service not managed by the Application is in the way.
+var errAdoptionRefused = errors.New("adoption refused")
+
+// errOwnedElsewhere is returned when the Knative Service in the way is controlled by another
+// controller. Retrying cannot fix it, so the Application only reports it.
+var errOwnedElsewhere = errors.New("knative service owned by another controller; cannot manage")
+
 // ApplicationReconciler reconciles a Application object
 type ApplicationReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// PlatformDomain is the domain Application hostnames are served under. Applications setting
+	// a hostname cannot be reconciled without it.
+	PlatformDomain string
+	// RevisionLabelPrefixes selects the Application labels copied onto the Knative revision
+	// template: a label is copied when its key starts with one of the prefixes.
+	RevisionLabelPrefixes []string
 }
 
 // +kubebuilder:rbac:groups=*,resources=*,verbs=*
@@ -115,8 +165,7 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(app, workloadv1alpha1.ApplicationFinalizer) {
 		l.Info("Adding finalizer")
-		controllerutil.AddFinalizer(app, workloadv1alpha1.ApplicationFinalizer)
-		if err := r.Update(ctx, app); err != nil {
+		if err := r.updateFinalizer(ctx, app, controllerutil.AddFinalizer); err != nil {
 			l.Error(err, "unable to add finalizer")
 			return ctrl.Result{}, err
 		}
@@ -126,6 +175,10 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// Reconcile the application resources (e.g., Knative Service, DomainMapping)
 	requeueNeeded, reconcileErr := r.reconcileResources(ctx, l, app)
 	if reconcileErr != nil {
+		if errors.Is(reconcileErr, errOwnedElsewhere) {
+			l.Info("Not managing the Knative Service", "reason", reconcileErr.Error())
+			return ctrl.Result{}, nil // The conditions explain it; a spec change triggers a new attempt.
+		}
 		if apierrors.IsConflict(reconcileErr) {
 			l.Info("Conflict during reconciliation, requeueing.", "application", req.NamespacedName)
 			return ctrl.Result{Requeue: true}, nil // Requeue on conflict
@@ -133,24 +186,44 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Return error to requeue, even if requeueNeeded is true, error takes precedence
 		return ctrl.Result{}, reconcileErr
 	}
-	if requeueNeeded {
-		l.Info("Requeueing reconciliation as Knative Service is not ready yet.")
+	// Update status to Ready only if all components are ready
+	if !setReadyCondition(app) || requeueNeeded {
+		l.Info("Requeueing reconciliation as the Application is not ready yet.")
 		// Don't update ObservedGeneration yet
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil // Requeue requested by reconcileResources
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	}
+	// Use embedded Status struct's ObservedGeneration field
+	app.Status.ObservedGeneration = app.Generation
+	l.Info("Application reconciled successfully")
+	return ctrl.Result{}, nil
+}
 
-	// Update status to Ready only if all components are ready
-	// Use embedded Status struct's SetCondition method
-	app.Status.SetCondition(metav1.Condition{
+// setReadyCondition sets the Ready condition from the sub-conditions: the Application is ready once
+// its Knative Service is ready and, when domains are configured, so are its DomainMappings.
+// It reports whether the Application is ready.
+func setReadyCondition(app *workloadv1alpha1.Application) bool {
+	ready := metav1.Condition{
 		Type:    workloadv1alpha1.ReadyConditionType,
 		Status:  metav1.ConditionTrue,
 		Reason:  workloadv1alpha1.ResourcesCreatedReason,
 		Message: fmt.Sprintf("Application %s is ready", app.Name),
-	})
-	// Use embedded Status struct's ObservedGeneration field
-	app.Status.ObservedGeneration = app.Generation
-	l.Info("Application reconciled successfully")
-	return ctrl.Result{}, nil
+	}
+	if cond := app.Status.GetCondition(workloadv1alpha1.KnativeServiceReadyConditionType); cond == nil ||
+		cond.Status != metav1.ConditionTrue {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = workloadv1alpha1.KnativeServiceNotReadyReason
+		ready.Message = "Waiting for the Knative Service to become ready"
+		if cond != nil {
+			ready.Message = cond.Message
+		}
+	} else if cond := app.Status.GetCondition(workloadv1alpha1.DomainMappingReadyConditionType); cond != nil &&
+		cond.Status != metav1.ConditionTrue && cond.Reason != workloadv1alpha1.DomainMappingNotConfiguredReason {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = workloadv1alpha1.DomainMappingNotReadyReason
+		ready.Message = cond.Message
+	}
+	app.Status.SetCondition(ready)
+	return ready.Status == metav1.ConditionTrue
 }
 
 // reconcileResources handles the creation/update of resources owned by the Application.
@@ -168,14 +241,37 @@ func (r *ApplicationReconciler) reconcileResources(
 		return false, fmt.Errorf("failed to reconcile Knative Service: %w", err)
 	}
 
-	// 2. Reconcile Domain Mapping
-	err = r.reconcileDomainMapping(ctx, l, app, ksvc)
+	// 2. Reconcile the optional ServiceMonitor
+	if err := r.reconcileServiceMonitor(ctx, l, app); err != nil {
+		return false, fmt.Errorf("failed to reconcile ServiceMonitor: %w", err)
+	}
+
+	// 3. Reconcile the optional PodDisruptionBudget
+	if err := r.reconcilePodDisruptionBudget(ctx, l, app); err != nil {
+		return false, fmt.Errorf("failed to reconcile PodDisruptionBudget: %w", err)
+	}
+
+	// 4. Reconcile Domain Mapping
+	domains, err := r.applicationDomains(app)
+	if err != nil {
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.DomainMappingReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  workloadv1alpha1.DomainMappingCreationFailedReason,
+			Message: err.Error(),
+		})
+		return false, err
+	}
+	// Refresh the URLs first so that a domain removed from the spec leaves the status in this
+	// reconcile, even when reconciling the remaining DomainMappings fails.
+	r.updateStatusURLs(l, app, ksvc, domains)
+	err = r.reconcileDomainMapping(ctx, l, app, ksvc, domains)
 	if err != nil {
 		return false, fmt.Errorf("failed to reconcile Domain Mapping: %w", err)
 	}
 
-	// 3. Update Status URLs
-	r.updateStatusURLs(l, app, ksvc)
+	// 5. Update Status revision
+	r.updateStatusRevision(app, ksvc)
 
 	// If we reached here without returning, no requeue is needed and no error occurred
 	return requeueNeeded, nil
@@ -198,19 +294,45 @@ func (r *ApplicationReconciler) reconcileKnativeService(
 		},
 	}
 
-	// Use controllerutil.CreateOrUpdate
-	opResult, err := controllerutil.CreateOrUpdate(ctx, r.Client, ksvc, func() error {
-		// Set the application label
-		if ksvc.Labels == nil {
-			ksvc.Labels = make(map[string]string)
+	if err := r.checkKnativeServiceAdoption(ctx, l, app, ksvc); err != nil {
+		reason := workloadv1alpha1.KnativeServiceStatusCheckFailedReason
+		switch {
+		case errors.Is(err, errOwnedElsewhere):
+			reason = workloadv1alpha1.KnativeServiceOwnedElsewhereReason
+		case errors.Is(err, errAdoptionRefused):
+			reason = workloadv1alpha1.KnativeServiceAdoptionRefusedReason
 		}
-		ksvc.Labels[workloadv1alpha1.ApplicationLabel] = app.Name
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.KnativeServiceReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		})
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.ReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		})
+		return nil, false, err
+	}
 
-		// Apply mutations from the Application spec
-		r.mutateKnativeService(app, ksvc)
+	opts, err := NewRevisionOptions(ctx, r.Client, app, r.RevisionLabelPrefixes)
+	if err != nil {
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.KnativeServiceReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  workloadv1alpha1.KnativeServiceCreationFailedReason,
+			Message: err.Error(),
+		})
+		return nil, false, err
+	}
 
-		// Set the controller reference
-		return controllerutil.SetControllerReference(app, ksvc, r.Scheme)
+	// Use controllerutil.CreateOrUpdate
+	var before *servingv1.Service
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.Client, ksvc, func() error {
+		before = ksvc.DeepCopy()
+		return applyKnativeService(app, ksvc, r.Scheme, opts)
 	})
 
 	if err != nil {
@@ -231,6 +353,13 @@ func (r *ApplicationReconciler) reconcileKnativeService(
 	if opResult != controllerutil.OperationResultNone {
 		l.Info("Knative Service reconciled", "operation", opResult)
 	}
+	if opResult == controllerutil.OperationResultUpdated && l.V(1).Enabled() {
+		if diff, err := KnativeServiceDiff(before, ksvc); err != nil {
+			l.V(1).Info("Failed to diff Knative Service", "error", err.Error())
+		} else {
+			l.V(1).Info("Knative Service changes", "diff", diff)
+		}
+	}
 
 	// --- Check Knative Service Readiness ---
 	// No need to re-fetch immediately after CreateOrUpdate unless status is critical
@@ -260,27 +389,139 @@ func (r *ApplicationReconciler) reconcileKnativeService(
 	ksvcReadyCond := latestKsvc.Status.GetCondition(servingv1.ServiceConditionReady)
 	if ksvcReadyCond == nil || ksvcReadyCond.Status != corev1.ConditionTrue {
 		l.Info("Knative Service is not ready yet, requeueing.", "service", ksvc.Name)
-		conds := latestKsvc.Status.GetConditions()
-		if len(conds) > 0 {
-			latestCond := conds[len(conds)-1]
-			cond := metav1.Condition{
-				Type:    workloadv1alpha1.ReadyConditionType,
-				Status:  metav1.ConditionFalse,
-				Reason:  workloadv1alpha1.KnativeServiceNotReadyReason,
-				Message: latestCond.Message,
-			}
-			app.Status.SetCondition(cond)
+		message := "Waiting for the Knative Service to become ready"
+		if conds := latestKsvc.Status.GetConditions(); len(conds) > 0 && conds[len(conds)-1].Message != "" {
+			message = conds[len(conds)-1].Message
 		}
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.KnativeServiceReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  workloadv1alpha1.KnativeServiceNotReadyReason,
+			Message: message,
+		})
 		return latestKsvc, true, nil // Requeue needed, return the latest ksvc
 	}
 	// Knative Service is Ready
 	l.Info("Knative Service is Ready", "service", ksvc.Name)
+	app.Status.SetCondition(metav1.Condition{
+		Type:    workloadv1alpha1.KnativeServiceReadyConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  workloadv1alpha1.KnativeServiceReadyReason,
+		Message: fmt.Sprintf("Knative Service %s is ready", ksvc.Name),
+	})
 	return latestKsvc, false, nil // Return the ready ksvc, no requeue, no error
 }
 
+// checkKnativeServiceAdoption makes sure an existing Knative Service with the Application's name is
+// only taken over when it is already controlled by the Application or explicitly marked for adoption.
+func (r *ApplicationReconciler) checkKnativeServiceAdoption(
+	ctx context.Context,
+	l logr.Logger,
+	app *workloadv1alpha1.Application,
+	ksvc *servingv1.Service,
+) error {
+	existing := &servingv1.Service{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(ksvc), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check for existing Knative Service %s: %w", ksvc.Name, err)
+	}
+	// Match the controller reference the same way controllerutil does (group, kind and name), so a
+	// service left behind by a previous incarnation of the Application is not treated as foreign.
+	if ref := metav1.GetControllerOf(existing); ref != nil && ref.Kind == "Application" && ref.Name == app.Name &&
+		schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).Group == workloadv1alpha1.GroupVersion.Group {
+		return nil
+	}
+	// Another controller owns it; taking it over would fail in SetControllerReference and leave
+	// both controllers fighting over the service, even when it is annotated for adoption.
+	if ref := metav1.GetControllerOf(existing); ref != nil {
+		return fmt.Errorf("%w: knative service %s/%s is controlled by %s %s",
+			errOwnedElsewhere, existing.Namespace, existing.Name, ref.Kind, ref.Name)
+	}
+	if existing.Annotations[workloadv1alpha1.AdoptAnnotation] == "true" {
+		l.Info("Adopting existing Knative Service", "service", existing.Name)
+		return nil
+	}
+	return fmt.Errorf("%w: knative service %s/%s already exists and is not managed by application %s; "+
+		"annotate it with %s=true to let the application adopt it, or delete it",
+		errAdoptionRefused, existing.Namespace, existing.Name, app.Name, workloadv1alpha1.AdoptAnnotation)
+}
+
+// RevisionOptions are the inputs of the Knative Service that do not come from the Application spec.
+// Changing them does not bump the Application generation.
+type RevisionOptions struct {
+	// LabelPrefixes selects the Application labels copied onto the revision template.
+	LabelPrefixes []string
+	// Suspended scales the Application to zero and keeps it off the external ingress.
+	Suspended bool
+	// ConfigHash is the hash of the watched ConfigMaps and Secrets the Application references,
+	// empty when it did not opt into config rollouts.
+	ConfigHash string
+}
+
+// NewRevisionOptions resolves the revision options of the Application from the cluster: the workspace
+// of the Application is read for its suspended state, and the watched ConfigMaps and Secrets it
+// references for the config hash. A namespace without a Workspace is not suspended.
+func NewRevisionOptions(
+	ctx context.Context, c client.Reader, app *workloadv1alpha1.Application, labelPrefixes []string,
+) (RevisionOptions, error) {
+	opts := RevisionOptions{LabelPrefixes: labelPrefixes}
+	workspace := &tenancyv1alpha1.Workspace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: app.Namespace}, workspace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return opts, fmt.Errorf("failed to get workspace %s: %w", app.Namespace, err)
+		}
+	} else {
+		opts.Suspended = workspace.Spec.Suspended
+	}
+	hash, err := configHash(ctx, c, app)
+	if err != nil {
+		return opts, err
+	}
+	opts.ConfigHash = hash
+	return opts, nil
+}
+
+// DesiredKnativeService returns the Knative Service the controller would write for the Application,
+// starting from the live one (nil when it does not exist yet). The live object is not modified.
+func DesiredKnativeService(
+	app *workloadv1alpha1.Application, live *servingv1.Service, scheme *runtime.Scheme, opts RevisionOptions,
+) (*servingv1.Service, error) {
+	desired := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: app.Name, Namespace: app.Namespace}}
+	if live != nil {
+		desired = live.DeepCopy()
+	}
+	if err := applyKnativeService(app, desired, scheme, opts); err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+// applyKnativeService labels the Knative Service, applies the Application spec and the revision
+// options to it and makes the Application its controller.
+func applyKnativeService(
+	app *workloadv1alpha1.Application, ksvc *servingv1.Service, scheme *runtime.Scheme, opts RevisionOptions,
+) error {
+	// Set the application label
+	if ksvc.Labels == nil {
+		ksvc.Labels = make(map[string]string)
+	}
+	ksvc.Labels[workloadv1alpha1.ApplicationLabel] = app.Name
+
+	// Apply mutations from the Application spec
+	mutateKnativeService(app, ksvc, opts.Suspended)
+	propagated := propagateRevisionLabels(app, ksvc, opts.LabelPrefixes)
+	setConfigHash(ksvc, opts.ConfigHash)
+	ksvc.Spec.Template.ObjectMeta.Name = revisionName(app, revisionVariant(propagated, opts.Suspended, opts.ConfigHash))
+
+	// Set the controller reference
+	return controllerutil.SetControllerReference(app, ksvc, scheme)
+}
+
 // mutateKnativeService applies the desired state from the Application spec to the Knative Service.
 // No error is returned as the operations are straightforward assignments.
-func (r *ApplicationReconciler) mutateKnativeService(app *workloadv1alpha1.Application, ksvc *servingv1.Service) {
+func mutateKnativeService(app *workloadv1alpha1.Application, ksvc *servingv1.Service, suspended bool) {
 	// Set the annotations for the Knative Service
 	if ksvc.Annotations == nil {
 		ksvc.Annotations = make(map[string]string)
@@ -288,17 +529,8 @@ func (r *ApplicationReconciler) mutateKnativeService(app *workloadv1alpha1.Appli
 	if ksvc.Spec.Template.ObjectMeta.Annotations == nil {
 		ksvc.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
 	}
-	// Default EnableTLS to true if nil or not set
-	enableTLS := workloadv1alpha1.DefaultEnableTLS
-	if app.Spec.EnableTLS != nil {
-		enableTLS = *app.Spec.EnableTLS
-	}
-	ksvc.Annotations[networking.DisableExternalDomainTLSAnnotationKey] = strconv.FormatBool(!enableTLS)
-	if enableTLS {
-		ksvc.Annotations[networking.HTTPProtocolAnnotationKey] = string(netv1alpha1.HTTPOptionRedirected)
-	} else {
-		ksvc.Annotations[networking.HTTPProtocolAnnotationKey] = string(netv1alpha1.HTTPOptionEnabled)
-	}
+	setTLSAnnotations(ksvc.Annotations, app)
+	setIngressClassAnnotation(ksvc.Annotations, app)
 	// Default Scale values if nil
 	minReplicas := int32(0) // Default minReplicas
 	if app.Spec.Scale.MinReplicas != nil {
@@ -311,11 +543,26 @@ func (r *ApplicationReconciler) mutateKnativeService(app *workloadv1alpha1.Appli
 	if minReplicas > maxReplicas {
 		maxReplicas = minReplicas // Ensure max is not less than min
 	}
+	initialScale := minReplicas
+	if app.Spec.Scale.InitialScale != nil {
+		initialScale = *app.Spec.Scale.InitialScale
+	}
+	// A suspended workspace lets its applications scale to zero and stops routing external traffic to them.
+	if suspended {
+		minReplicas = 0
+		initialScale = 0
+		if ksvc.Labels == nil {
+			ksvc.Labels = make(map[string]string)
+		}
+		ksvc.Labels[networking.VisibilityLabelKey] = serving.VisibilityClusterLocal
+	} else {
+		delete(ksvc.Labels, networking.VisibilityLabelKey)
+	}
 
 	// Only set autoscaling annotations on the template, not copying all service annotations
 	ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.MinScaleAnnotationKey] = strconv.Itoa(int(minReplicas))
 	ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.MaxScaleAnnotationKey] = strconv.Itoa(int(maxReplicas))
-	ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.InitialScaleAnnotationKey] = strconv.Itoa(int(minReplicas))
+	ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.InitialScaleAnnotationKey] = strconv.Itoa(int(initialScale))
 	metric := workloadv1alpha1.MetricConcurrency
 	if app.Spec.Scale.Metric != "" {
 		metric = app.Spec.Scale.Metric
@@ -323,18 +570,28 @@ func (r *ApplicationReconciler) mutateKnativeService(app *workloadv1alpha1.Appli
 	ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.MetricAnnotationKey] = string(metric)
 	ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.ClassAnnotationKey] = metric.GetClass()
 
-	if app.Spec.Scale.Target != nil {
-		ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.TargetAnnotationKey] = strconv.Itoa(int(*app.Spec.Scale.Target))
+	setProfileAnnotations(ksvc.Spec.Template.ObjectMeta.Annotations, app.Spec.Profile)
+	// The default Target is applied here rather than by the defaulter, so a set Target is always
+	// explicit and wins over the profile while an unset one leaves the profile target in place.
+	_, profileTarget := profileAnnotations[app.Spec.Profile][autoscaling.TargetAnnotationKey]
+	if target := app.Spec.Scale.Target; target != nil {
+		ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.TargetAnnotationKey] = strconv.Itoa(int(*target))
+	} else if !profileTarget && app.Spec.Scale.TargetUtilizationPercentage == nil {
+		ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.TargetAnnotationKey] =
+			strconv.Itoa(int(workloadv1alpha1.DefaultTargetUtilization))
 	}
 	if app.Spec.Scale.TargetUtilizationPercentage != nil {
 		ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.TargetUtilizationPercentageKey] =
 			strconv.Itoa(int(*app.Spec.Scale.TargetUtilizationPercentage))
-	} else if metric == workloadv1alpha1.MetricCPU || metric == workloadv1alpha1.MetricMemory {
+	} else if _, fromProfile := ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.TargetUtilizationPercentageKey]; !fromProfile &&
+		(metric == workloadv1alpha1.MetricCPU || metric == workloadv1alpha1.MetricMemory) {
 		defaultTarget := workloadv1alpha1.DefaultTargetUtilization
 		ksvc.Spec.Template.ObjectMeta.Annotations[autoscaling.TargetUtilizationPercentageKey] =
 			strconv.Itoa(int(defaultTarget))
 	}
 
+	setScrapeAnnotations(ksvc.Spec.Template.ObjectMeta.Annotations, app.Spec.Metrics)
+
 	rolloutDuration := workloadv1alpha1.DefaultRolloutDuration.String() // Default rollout duration
 	if app.Spec.RolloutDuration != nil {
 		rolloutDuration = app.Spec.RolloutDuration.Duration.String() // Access Duration field
@@ -343,7 +600,26 @@ func (r *ApplicationReconciler) mutateKnativeService(app *workloadv1alpha1.Appli
 
 	// Configure the template spec
 	ksvc.Spec.Template.Spec.ImagePullSecrets = app.Spec.ImagePullSecrets
-	ksvc.Spec.Template.Spec.Containers = app.Spec.Containers
+	// Knative uses the revision timeout as the termination grace period of the pods.
+	ksvc.Spec.Template.Spec.TimeoutSeconds = app.Spec.TerminationGracePeriodSeconds
+	ksvc.Spec.Template.Spec.EnableServiceLinks = app.Spec.EnableServiceLinks
+	containers := make([]corev1.Container, len(app.Spec.Containers))
+	for i := range app.Spec.Containers {
+		containers[i] = *app.Spec.Containers[i].DeepCopy()
+	}
+	ksvc.Spec.Template.Spec.Containers = containers
+	ksvc.Spec.Template.Spec.Volumes = nil
+	for i := range containers {
+		containers[i].Ports = servingPorts(containers[i].Ports)
+	}
+	interpolateAppPort(&ksvc.Spec.Template.Spec.PodSpec)
+	ksvc.Spec.Template.Spec.TopologySpreadConstraints = topologySpreadConstraints(app)
+	if app.Spec.TrustBundleConfigMap != "" {
+		injectTrustBundle(&ksvc.Spec.Template.Spec.PodSpec, app.Spec.TrustBundleConfigMap)
+	}
+	if app.Spec.ExposePodMetadata {
+		injectPodMetadataEnv(&ksvc.Spec.Template.Spec.PodSpec)
+	}
 	// Ensure labels from the service are propagated to the template
 	if ksvc.Spec.Template.ObjectMeta.Labels == nil {
 		ksvc.Spec.Template.ObjectMeta.Labels = make(map[string]string)
@@ -351,15 +627,425 @@ func (r *ApplicationReconciler) mutateKnativeService(app *workloadv1alpha1.Appli
 	for k, v := range ksvc.Labels { // Copy labels from service meta
 		ksvc.Spec.Template.ObjectMeta.Labels[k] = v
 	}
+	// Visibility only applies to the route, keep it off the revision template
+	delete(ksvc.Spec.Template.ObjectMeta.Labels, networking.VisibilityLabelKey)
 	// Do NOT copy all service annotations to the template (prevents unnecessary revision bumps)
 }
 
-// reconcileDomainMapping handles the reconciliation of the DomainMapping for the Application.
+// propagateRevisionLabels copies the Application labels whose key starts with one of the prefixes
+// onto the revision template, and drops the ones the Application no longer has. Labels the controller
+// sets on the Knative Service and labels owned by Knative are never overwritten. It returns the
+// copied labels.
+func propagateRevisionLabels(
+	app *workloadv1alpha1.Application, ksvc *servingv1.Service, prefixes []string,
+) map[string]string {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	allowed := func(key string) bool {
+		if _, managed := ksvc.Labels[key]; managed || strings.HasPrefix(key, serving.GroupName+"/") {
+			return false
+		}
+		return slices.ContainsFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) })
+	}
+	labels := ksvc.Spec.Template.ObjectMeta.Labels
+	for k := range labels {
+		if _, ok := app.Labels[k]; !ok && allowed(k) {
+			delete(labels, k)
+		}
+	}
+	propagated := make(map[string]string)
+	for k, v := range app.Labels {
+		if allowed(k) {
+			labels[k] = v
+			propagated[k] = v
+		}
+	}
+	return propagated
+}
+
+// profileAnnotations are the revision template annotations each Application profile expands into.
+var profileAnnotations = map[workloadv1alpha1.Profile]map[string]string{
+	workloadv1alpha1.ProfileLowLatency: {
+		autoscaling.TargetAnnotationKey:              "50",
+		autoscaling.WindowAnnotationKey:              "30s",
+		autoscaling.ScaleDownDelayAnnotationKey:      "5m",
+		autoscaling.ScaleToZeroPodRetentionPeriodKey: "10m",
+	},
+	workloadv1alpha1.ProfileBatch: {
+		autoscaling.TargetAnnotationKey:                   "10",
+		autoscaling.WindowAnnotationKey:                   "120s",
+		autoscaling.PanicThresholdPercentageAnnotationKey: "1000",
+		autoscaling.TargetBurstCapacityKey:                "0",
+	},
+	workloadv1alpha1.ProfileCostOptimized: {
+		autoscaling.TargetAnnotationKey:              "100",
+		autoscaling.TargetUtilizationPercentageKey:   "90",
+		autoscaling.ScaleDownDelayAnnotationKey:      "0s",
+		autoscaling.ScaleToZeroPodRetentionPeriodKey: "0s",
+	},
+}
+
+// setProfileAnnotations replaces the annotations of any previous profile with those of the given one,
+// so switching or removing the profile does not leave stale settings on the revision template.
+func setProfileAnnotations(annotations map[string]string, profile workloadv1alpha1.Profile) {
+	for _, settings := range profileAnnotations {
+		for key := range settings {
+			delete(annotations, key)
+		}
+	}
+	maps.Copy(annotations, profileAnnotations[profile])
+}
+
+// setTLSAnnotations sets the external domain TLS and HTTP protocol annotations shared by the
+// Knative Service and its DomainMappings. EnableTLS defaults to true, and so does TLSRedirect
+// when TLS is enabled.
+func setTLSAnnotations(annotations map[string]string, app *workloadv1alpha1.Application) {
+	enableTLS := workloadv1alpha1.DefaultEnableTLS
+	if app.Spec.EnableTLS != nil {
+		enableTLS = *app.Spec.EnableTLS
+	}
+	redirect := enableTLS
+	if enableTLS && app.Spec.TLSRedirect != nil {
+		redirect = *app.Spec.TLSRedirect
+	}
+	annotations[networking.DisableExternalDomainTLSAnnotationKey] = strconv.FormatBool(!enableTLS)
+	if redirect {
+		annotations[networking.HTTPProtocolAnnotationKey] = string(netv1alpha1.HTTPOptionRedirected)
+	} else {
+		annotations[networking.HTTPProtocolAnnotationKey] = string(netv1alpha1.HTTPOptionEnabled)
+	}
+}
+
+// setIngressClassAnnotation selects the ingress class of the application, removing a previous
+// selection so that unsetting IngressClass falls back to the cluster default.
+func setIngressClassAnnotation(annotations map[string]string, app *workloadv1alpha1.Application) {
+	if app.Spec.IngressClass == "" {
+		delete(annotations, networking.IngressClassAnnotationKey)
+		return
+	}
+	annotations[networking.IngressClassAnnotationKey] = app.Spec.IngressClass
+}
+
+// revisionName returns the name of the revision for the current Application generation when a
+// revision name prefix is configured, or an empty name to let Knative generate one.
+// Knative requires the template name to change with every template change. The generation covers
+// the spec; the variant, appended when set, covers the template inputs that do not bump it.
+func revisionName(app *workloadv1alpha1.Application, variant string) string {
+	if app.Spec.RevisionNamePrefix == "" {
+		return ""
+	}
+	name := fmt.Sprintf("%s-%s%d", app.Name, app.Spec.RevisionNamePrefix, app.Generation)
+	if variant != "" {
+		name += "-" + variant
+	}
+	return name
+}
+
+// revisionVariant hashes the revision template inputs that do not bump the Application generation,
+// the propagated labels, the suspended state and the config hash, into a short suffix of the revision name. It is empty when there
+// are none, so the revisions of plain Applications keep their "<name>-<prefix><generation>" name.
+func revisionVariant(propagated map[string]string, suspended bool, configHash string) string {
+	if len(propagated) == 0 && !suspended && configHash == "" {
+		return ""
+	}
+	h := sha256.New()
+	if suspended {
+		_, _ = fmt.Fprintln(h, "suspended")
+	}
+	if configHash != "" {
+		_, _ = fmt.Fprintf(h, "config:%s\n", configHash)
+	}
+	for _, k := range slices.Sorted(maps.Keys(propagated)) {
+		_, _ = fmt.Fprintf(h, "label:%s=%s\n", k, propagated[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:revisionVariantLength]
+}
+
+// setScrapeAnnotations adds the Prometheus scrape annotations for the configured metrics endpoint,
+// removing them when metrics are not configured.
+func setScrapeAnnotations(annotations map[string]string, metrics *workloadv1alpha1.Metrics) {
+	if metrics == nil {
+		delete(annotations, prometheusScrapeAnnotation)
+		delete(annotations, prometheusPortAnnotation)
+		delete(annotations, prometheusPathAnnotation)
+		return
+	}
+	path := metrics.Path
+	if path == "" {
+		path = workloadv1alpha1.DefaultMetricsPath
+	}
+	annotations[prometheusScrapeAnnotation] = "true"
+	annotations[prometheusPortAnnotation] = strconv.Itoa(int(metrics.Port))
+	annotations[prometheusPathAnnotation] = path
+}
+
+// reconcileServiceMonitor creates a Prometheus Operator ServiceMonitor for the Application when it is
+// requested and the ServiceMonitor CRD is installed, and removes a previously created one otherwise.
+func (r *ApplicationReconciler) reconcileServiceMonitor(
+	ctx context.Context,
+	l logr.Logger,
+	app *workloadv1alpha1.Application,
+) error {
+	l = l.WithValues("resource", "ServiceMonitor")
+	if _, err := r.RESTMapper().RESTMapping(serviceMonitorGVK.GroupKind(), serviceMonitorGVK.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			if app.Spec.Metrics != nil && app.Spec.Metrics.ServiceMonitor {
+				l.Info("ServiceMonitor requested but the Prometheus Operator CRD is not installed, skipping")
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to discover ServiceMonitor CRD: %w", err)
+	}
+
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(serviceMonitorGVK)
+	sm.SetName(app.Name)
+	sm.SetNamespace(app.Namespace)
+
+	if app.Spec.Metrics == nil || !app.Spec.Metrics.ServiceMonitor {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sm), sm); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(sm, app) {
+			return nil
+		}
+		l.Info("Deleting ServiceMonitor no longer requested")
+		return client.IgnoreNotFound(r.Delete(ctx, sm))
+	}
+
+	path := app.Spec.Metrics.Path
+	if path == "" {
+		path = workloadv1alpha1.DefaultMetricsPath
+	}
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.Client, sm, func() error {
+		labels := sm.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[workloadv1alpha1.ApplicationLabel] = app.Name
+		sm.SetLabels(labels)
+		// Knative labels the private Service of every revision with the service name.
+		sm.Object["spec"] = map[string]any{
+			"selector": map[string]any{
+				"matchLabels": map[string]any{
+					serving.ServiceLabelKey:          app.Name,
+					servingnetworking.ServiceTypeKey: string(servingnetworking.ServiceTypePrivate),
+				},
+			},
+			"endpoints": []any{
+				map[string]any{
+					"targetPort": int64(app.Spec.Metrics.Port),
+					"path":       path,
+				},
+			},
+		}
+		return controllerutil.SetControllerReference(app, sm, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if opResult != controllerutil.OperationResultNone {
+		l.Info("ServiceMonitor reconciled", "operation", opResult)
+	}
+	return nil
+}
+
+// reconcilePodDisruptionBudget creates a PodDisruptionBudget selecting the pods of every revision of
+// the Application when a disruption budget is requested, and removes a previously created one otherwise.
+func (r *ApplicationReconciler) reconcilePodDisruptionBudget(
+	ctx context.Context,
+	l logr.Logger,
+	app *workloadv1alpha1.Application,
+) error {
+	l = l.WithValues("resource", "PodDisruptionBudget")
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.Name,
+			Namespace: app.Namespace,
+		},
+	}
+
+	if app.Spec.DisruptionBudget == nil {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pdb), pdb); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(pdb, app) {
+			return nil
+		}
+		l.Info("Deleting PodDisruptionBudget no longer requested")
+		return client.IgnoreNotFound(r.Delete(ctx, pdb))
+	}
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.Client, pdb, func() error {
+		if pdb.Labels == nil {
+			pdb.Labels = make(map[string]string)
+		}
+		pdb.Labels[workloadv1alpha1.ApplicationLabel] = app.Name
+		// Knative labels the pods of every revision with the service name, so the selector
+		// keeps matching as revisions roll.
+		pdb.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{serving.ServiceLabelKey: app.Name},
+		}
+		pdb.Spec.MinAvailable = app.Spec.DisruptionBudget.MinAvailable
+		pdb.Spec.MaxUnavailable = app.Spec.DisruptionBudget.MaxUnavailable
+		return controllerutil.SetControllerReference(app, pdb, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if opResult != controllerutil.OperationResultNone {
+		l.Info("PodDisruptionBudget reconciled", "operation", opResult)
+	}
+	return nil
+}
+
+// injectTrustBundle mounts the CA bundle from the given ConfigMap into every container
+// through a projected volume and points SSL_CERT_FILE at it.
+func injectTrustBundle(podSpec *corev1.PodSpec, configMapName string) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: workloadv1alpha1.TrustBundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+						Items: []corev1.KeyToPath{{
+							Key:  workloadv1alpha1.TrustBundleKey,
+							Path: workloadv1alpha1.TrustBundleKey,
+						}},
+					},
+				}},
+			},
+		},
+	})
+	certFile := workloadv1alpha1.TrustBundleMountPath + "/" + workloadv1alpha1.TrustBundleKey
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      workloadv1alpha1.TrustBundleVolumeName,
+			MountPath: workloadv1alpha1.TrustBundleMountPath,
+			ReadOnly:  true,
+		})
+		if !slices.ContainsFunc(c.Env, func(e corev1.EnvVar) bool { return e.Name == "SSL_CERT_FILE" }) {
+			c.Env = append(c.Env, corev1.EnvVar{Name: "SSL_CERT_FILE", Value: certFile})
+		}
+	}
+}
+
+// KnativeServiceDiff returns a field-level diff of the parts of the Knative Service managed by
+// the controller, so drift corrected by a reconcile can be traced in the logs.
+func KnativeServiceDiff(before, after *servingv1.Service) (string, error) {
+	type managed struct {
+		Labels          map[string]string
+		Annotations     map[string]string
+		OwnerReferences []metav1.OwnerReference
+		Spec            servingv1.ServiceSpec
+	}
+	managedFields := func(ksvc *servingv1.Service) managed {
+		return managed{
+			Labels:          ksvc.Labels,
+			Annotations:     ksvc.Annotations,
+			OwnerReferences: ksvc.OwnerReferences,
+			Spec:            ksvc.Spec,
+		}
+	}
+	return kmp.ShortDiff(managedFields(before), managedFields(after))
+}
+
+// servingPorts returns the port Knative routes traffic to. Knative accepts a single port per
+// container, so when several are declared only the one named http1 or h2c is kept; the others
+// are informational and remain reachable inside the pod.
+func servingPorts(ports []corev1.ContainerPort) []corev1.ContainerPort {
+	if len(ports) <= 1 {
+		return ports
+	}
+	for _, port := range ports {
+		if workloadv1alpha1.ServingPortNames.Has(port.Name) {
+			return []corev1.ContainerPort{port}
+		}
+	}
+	return ports
+}
+
+// interpolateAppPort replaces $(FCP_APP_PORT) in the container environment variable values with
+// the port of the ingress container, the one declaring a port, or the Knative default port.
+// Kubernetes leaves references to undefined variables untouched, so this has to be resolved here.
+func interpolateAppPort(podSpec *corev1.PodSpec) {
+	ref := "$(" + workloadv1alpha1.AppPortVariable + ")"
+	port := int32(servingv1.DefaultUserPort)
+	for _, c := range podSpec.Containers {
+		if len(c.Ports) > 0 {
+			port = c.Ports[0].ContainerPort
+			break
+		}
+	}
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		for j := range c.Env {
+			if c.Env[j].ValueFrom == nil && strings.Contains(c.Env[j].Value, ref) {
+				c.Env[j].Value = strings.ReplaceAll(c.Env[j].Value, ref, strconv.Itoa(int(port)))
+			}
+		}
+	}
+}
+
+// topologySpreadConstraints returns the Application constraints for the revision pods, selecting
+// the pods of the Application in the constraints that do not set a label selector.
+func topologySpreadConstraints(app *workloadv1alpha1.Application) []corev1.TopologySpreadConstraint {
+	if len(app.Spec.TopologySpreadConstraints) == 0 {
+		return nil
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, len(app.Spec.TopologySpreadConstraints))
+	for i := range app.Spec.TopologySpreadConstraints {
+		constraints[i] = *app.Spec.TopologySpreadConstraints[i].DeepCopy()
+		if constraints[i].LabelSelector == nil {
+			constraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{serving.ServiceLabelKey: app.Name},
+			}
+		}
+	}
+	return constraints
+}
+
+// injectPodMetadataEnv adds the downward API pod metadata variables to every container,
+// leaving any variable the user already defined untouched.
+func injectPodMetadataEnv(podSpec *corev1.PodSpec) {
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		for _, env := range workloadv1alpha1.PodMetadataEnv {
+			if slices.ContainsFunc(c.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name }) {
+				continue
+			}
+			c.Env = append(c.Env, *env.DeepCopy())
+		}
+	}
+}
+
+// applicationDomains returns the custom domains of the Application followed by its hostname under
+// the platform domain, which are the names of its DomainMappings.
+func (r *ApplicationReconciler) applicationDomains(app *workloadv1alpha1.Application) ([]string, error) {
+	if app.Spec.Hostname == "" {
+		return app.Spec.Domains, nil
+	}
+	if r.PlatformDomain == "" {
+		return nil, fmt.Errorf("hostname %q is set but the manager has no --platform-domain to serve it under",
+			app.Spec.Hostname)
+	}
+	domain := app.Spec.Hostname + "." + r.PlatformDomain
+	if slices.Contains(app.Spec.Domains, domain) {
+		return app.Spec.Domains, nil
+	}
+	return append(slices.Clone(app.Spec.Domains), domain), nil
+}
+
+// reconcileDomainMapping handles the reconciliation of the DomainMappings of the given domains.
 func (r *ApplicationReconciler) reconcileDomainMapping(
 	ctx context.Context,
 	l logr.Logger,
 	app *workloadv1alpha1.Application,
 	ksvc *servingv1.Service, // Knative Service must be ready or reconciled before this
+	domains []string,
 ) error {
 	l = l.WithValues("resource", "DomainMapping")
 	if ksvc == nil {
@@ -374,9 +1060,10 @@ func (r *ApplicationReconciler) reconcileDomainMapping(
 		return fmt.Errorf("knative service is nil, cannot proceed with domain mapping reconciliation")
 	}
 
-	l = l.WithValues("domains", app.Spec.Domains)
+	l = l.WithValues("domains", domains)
 	l.Info("Reconciling")
-	for _, domain := range app.Spec.Domains {
+	var notReady []string
+	for _, domain := range domains {
 		// --- Check for conflicting DomainMapping before CreateOrUpdate ---
 		existingDM := &servingv1beta1.DomainMapping{}
 		err := r.Get(ctx, client.ObjectKey{Name: domain, Namespace: app.Namespace}, existingDM)
@@ -429,16 +1116,8 @@ func (r *ApplicationReconciler) reconcileDomainMapping(
 			if dm.Annotations == nil {
 				dm.Annotations = make(map[string]string)
 			}
-			enableTLS := workloadv1alpha1.DefaultEnableTLS
-			if app.Spec.EnableTLS != nil {
-				enableTLS = *app.Spec.EnableTLS
-			}
-			dm.Annotations[networking.DisableExternalDomainTLSAnnotationKey] = strconv.FormatBool(!enableTLS)
-			if enableTLS {
-				dm.Annotations[networking.HTTPProtocolAnnotationKey] = string(netv1alpha1.HTTPOptionRedirected)
-			} else {
-				dm.Annotations[networking.HTTPProtocolAnnotationKey] = string(netv1alpha1.HTTPOptionEnabled)
-			}
+			setTLSAnnotations(dm.Annotations, app)
+			setIngressClassAnnotation(dm.Annotations, app)
 
 			// Set the reference to the Knative Service
 			dm.Spec.Ref = duckv1.KReference{
@@ -465,14 +1144,35 @@ func (r *ApplicationReconciler) reconcileDomainMapping(
 		if opResult != controllerutil.OperationResultNone {
 			l.Info("DomainMapping reconciled", "operation", opResult)
 		}
+		if !dm.IsReady() {
+			notReady = append(notReady, dm.Name)
+		}
+	}
+
+	switch {
+	case len(domains) == 0:
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.DomainMappingReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  workloadv1alpha1.DomainMappingNotConfiguredReason,
+			Message: "DomainMapping is not configured in the Application spec.",
+		})
+	case len(notReady) > 0:
+		app.Status.SetCondition(metav1.Condition{
+			Type:    workloadv1alpha1.DomainMappingReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  workloadv1alpha1.DomainMappingNotReadyReason,
+			Message: fmt.Sprintf("Waiting for DomainMappings to become ready: %s", strings.Join(notReady, ", ")),
+		})
+	default:
 		app.Status.SetCondition(metav1.Condition{
 			Type:    workloadv1alpha1.DomainMappingReadyConditionType,
 			Status:  metav1.ConditionTrue,
 			Reason:  workloadv1alpha1.DomainMappingReadyReason,
-			Message: fmt.Sprintf("DomainMapping %s created/updated", dm.Name),
+			Message: "All DomainMappings are ready",
 		})
 	}
-	if err := r.cleanupOwnedDomainMappings(ctx, l, app); err != nil {
+	if err := r.cleanupOwnedDomainMappings(ctx, l, app, domains); err != nil {
 		l.Error(err, "Failed to cleanup old DomainMappings")
 		app.Status.SetCondition(metav1.Condition{
 			Type:    workloadv1alpha1.DomainMappingReadyConditionType,
@@ -485,52 +1185,21 @@ func (r *ApplicationReconciler) reconcileDomainMapping(
 	return nil
 }
 
-// cleanupOwnedDomainMappings deletes any DomainMapping resources owned by the Application.
+// cleanupOwnedDomainMappings deletes the DomainMappings owned by the Application for domains it no longer serves.
 func (r *ApplicationReconciler) cleanupOwnedDomainMappings(
 	ctx context.Context,
 	l logr.Logger,
 	app *workloadv1alpha1.Application,
+	domains []string,
 ) error {
-	dmList := &servingv1beta1.DomainMappingList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(app.Namespace),
-		client.MatchingLabels{workloadv1alpha1.ApplicationLabel: app.Name},
-	}
-	if err := r.List(ctx, dmList, listOpts...); err != nil {
-		l.Error(err, "Failed to list DomainMappings for cleanup check")
-		// Don't block reconciliation, but log the error and return it.
-		return fmt.Errorf("failed to list DomainMappings for cleanup: %w", err)
-	}
-
-	var deleteErrors []error
-	for i := range dmList.Items {
-		dm := dmList.Items[i] // Create a local copy for the closure/delete call
-		// Check if the DomainMapping is owned by the current Application instance.
-		if metav1.IsControlledBy(&dm, app) && !slices.Contains(app.Spec.Domains, dm.Name) {
-			l.Info("Deleting orphaned DomainMapping", "domainMapping", dm.Name)
-			if err := r.Delete(ctx, &dm); err != nil && !apierrors.IsNotFound(err) {
-				l.Error(err, "Failed to delete orphaned DomainMapping", "domainMapping", dm.Name)
-				deleteErrors = append(deleteErrors, fmt.Errorf("failed to delete DomainMapping %s: %w", dm.Name, err))
-			}
-		} else {
-			l.V(1).Info("Found DomainMapping with application label but different/no owner, skipping deletion.",
-				"domainMapping", dm.Name)
-		}
-	}
-
-	// Clear the DomainMappingReady condition if it was previously set true
-	app.Status.SetCondition(metav1.Condition{
-		Type:    workloadv1alpha1.DomainMappingReadyConditionType,
-		Status:  metav1.ConditionFalse,
-		Reason:  workloadv1alpha1.DomainMappingNotConfiguredReason,
-		Message: "DomainMapping is not configured in the Application spec.",
-	})
-
-	// If there were errors deleting DomainMappings, return an aggregated error
-	if len(deleteErrors) > 0 {
-		return kerrors.NewAggregate(deleteErrors)
-	}
-	return nil
+	return cleanup.OwnedResources(logf.IntoContext(ctx, l), r.Client, app, cleanup.Selector{
+		Namespace:  app.Namespace,
+		LabelKey:   workloadv1alpha1.ApplicationLabel,
+		LabelValue: app.Name,
+		Keep: func(obj client.Object) bool {
+			return slices.Contains(domains, obj.GetName())
+		},
+	}, &servingv1beta1.DomainMappingList{})
 }
 
 // updateStatusURLs updates the Application status with the relevant URLs.
@@ -538,6 +1207,7 @@ func (r *ApplicationReconciler) updateStatusURLs(
 	l logr.Logger,
 	app *workloadv1alpha1.Application,
 	ksvc *servingv1.Service,
+	domains []string,
 ) { // Removed error return type
 	urls := []string{}
 
@@ -552,7 +1222,7 @@ func (r *ApplicationReconciler) updateStatusURLs(
 	}
 
 	// Add the custom domain URL if configured and DomainMapping is ready
-	if len(app.Spec.Domains) > 0 {
+	if len(domains) > 0 {
 		scheme := "http"
 		// Default EnableTLS to true if nil or not set
 		enableTLS := workloadv1alpha1.DefaultEnableTLS
@@ -562,7 +1232,7 @@ func (r *ApplicationReconciler) updateStatusURLs(
 		if enableTLS {
 			scheme = "https"
 		}
-		for _, domain := range app.Spec.Domains {
+		for _, domain := range domains {
 			// Construct the custom domain URL
 			urls = append(urls, fmt.Sprintf("%s://%s", scheme, domain))
 		}
@@ -575,6 +1245,160 @@ func (r *ApplicationReconciler) updateStatusURLs(
 	}
 }
 
+// updateStatusRevision records the latest ready revision reported by the Knative Service.
+func (r *ApplicationReconciler) updateStatusRevision(app *workloadv1alpha1.Application, ksvc *servingv1.Service) {
+	if ksvc == nil {
+		return
+	}
+	app.Status.LatestReadyRevision = ksvc.Status.LatestReadyRevisionName
+	app.Status.Traffic = trafficStatus(ksvc)
+}
+
+// trafficStatus reports the traffic split of the Knative Route. The Knative Service mirrors the
+// status of its Route, and unlike the Route it is watched, so it is read from there.
+func trafficStatus(ksvc *servingv1.Service) []workloadv1alpha1.TrafficStatus {
+	var traffic []workloadv1alpha1.TrafficStatus
+	for _, target := range ksvc.Status.Traffic {
+		status := workloadv1alpha1.TrafficStatus{
+			RevisionName:   target.RevisionName,
+			Tag:            target.Tag,
+			LatestRevision: ptr.Deref(target.LatestRevision, false),
+		}
+		if target.Percent != nil {
+			status.Percent = *target.Percent
+		}
+		traffic = append(traffic, status)
+	}
+	return traffic
+}
+
+// revisionToApplication maps a Knative Revision to the Application that owns its service,
+// using the application label propagated from the service template.
+func (r *ApplicationReconciler) revisionToApplication(_ context.Context, obj client.Object) []reconcile.Request {
+	appName, ok := obj.GetLabels()[workloadv1alpha1.ApplicationLabel]
+	if !ok || appName == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: appName, Namespace: obj.GetNamespace()},
+	}}
+}
+
+// configRef is a ConfigMap or Secret referenced from the env of the containers of an Application.
+type configRef struct {
+	kind string
+	name string
+}
+
+// configRefs returns the ConfigMaps and Secrets referenced from the env of the containers, sorted.
+func configRefs(app *workloadv1alpha1.Application) []configRef {
+	refs := sets.New[configRef]()
+	for _, container := range app.Spec.Containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				refs.Insert(configRef{kind: "ConfigMap", name: envFrom.ConfigMapRef.Name})
+			}
+			if envFrom.SecretRef != nil {
+				refs.Insert(configRef{kind: "Secret", name: envFrom.SecretRef.Name})
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				refs.Insert(configRef{kind: "ConfigMap", name: env.ValueFrom.ConfigMapKeyRef.Name})
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				refs.Insert(configRef{kind: "Secret", name: env.ValueFrom.SecretKeyRef.Name})
+			}
+		}
+	}
+	return slices.SortedFunc(maps.Keys(refs), func(a, b configRef) int {
+		return cmp.Or(cmp.Compare(a.kind, b.kind), cmp.Compare(a.name, b.name))
+	})
+}
+
+// configHash hashes the resource versions of the referenced ConfigMaps and Secrets labelled with
+// ConfigRolloutLabel, so any change to them changes the hash. Only their metadata is read, the data
+// of the Secrets is never cached. It returns "" when the Application did not opt in or references
+// no watched object; missing objects are left out as Knative reports them on the revision.
+func configHash(ctx context.Context, c client.Reader, app *workloadv1alpha1.Application) (string, error) {
+	if !app.Spec.RolloutOnConfigChange {
+		return "", nil
+	}
+	h := sha256.New()
+	watched := false
+	for _, ref := range configRefs(app) {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(ref.kind))
+		if err := c.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: ref.name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get %s %s: %w", ref.kind, ref.name, err)
+		}
+		if obj.Labels[workloadv1alpha1.ConfigRolloutLabel] != "true" {
+			continue
+		}
+		watched = true
+		_, _ = fmt.Fprintf(h, "%s/%s=%s\n", ref.kind, ref.name, obj.ResourceVersion)
+	}
+	if !watched {
+		return "", nil
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// setConfigHash records the config hash on the revision template, which makes Knative create a new
+// revision when it changes. The hash is also part of the revision name variant.
+func setConfigHash(ksvc *servingv1.Service, hash string) {
+	if hash == "" {
+		delete(ksvc.Spec.Template.Annotations, workloadv1alpha1.ConfigHashAnnotation)
+		return
+	}
+	ksvc.Spec.Template.Annotations[workloadv1alpha1.ConfigHashAnnotation] = hash
+}
+
+// workspaceToApplications maps a Workspace to the Applications of its namespace.
+func (r *ApplicationReconciler) workspaceToApplications(ctx context.Context, obj client.Object) []reconcile.Request {
+	apps := &workloadv1alpha1.ApplicationList{}
+	if err := r.List(ctx, apps, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "unable to list Applications of the workspace", "workspace", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(apps.Items))
+	for _, app := range apps.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: app.Name, Namespace: app.Namespace},
+		})
+	}
+	return requests
+}
+
+// configToApplications maps a watched ConfigMap or Secret to the Applications of its namespace that
+// opted into config rollouts and reference it.
+func (r *ApplicationReconciler) configToApplications(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		apps := &workloadv1alpha1.ApplicationList{}
+		if err := r.List(ctx, apps, client.InNamespace(obj.GetNamespace())); err != nil {
+			logf.FromContext(ctx).Error(err, "unable to list Applications for a config change",
+				"kind", kind, "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, app := range apps.Items {
+			if app.Spec.RolloutOnConfigChange &&
+				slices.Contains(configRefs(&app), configRef{kind: kind, name: obj.GetName()}) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: app.Name, Namespace: app.Namespace},
+				})
+			}
+		}
+		return requests
+	}
+}
+
 // reconcileDeletion handles the cleanup when an Application is marked for deletion.
 func (r *ApplicationReconciler) reconcileDeletion(
 	ctx context.Context,
@@ -583,9 +1407,12 @@ func (r *ApplicationReconciler) reconcileDeletion(
 ) error {
 	l.Info("Reconciling Application deletion", "application", app.Name)
 	if controllerutil.ContainsFinalizer(app, workloadv1alpha1.ApplicationFinalizer) {
+		if err := r.cleanupLabeledResources(ctx, l, app); err != nil {
+			// Keep the finalizer so the cleanup is retried.
+			return err
+		}
 		l.Info("Removing finalizer")
-		controllerutil.RemoveFinalizer(app, workloadv1alpha1.ApplicationFinalizer)
-		if err := r.Update(ctx, app); err != nil {
+		if err := r.updateFinalizer(ctx, app, controllerutil.RemoveFinalizer); client.IgnoreNotFound(err) != nil {
 			l.Error(err, "unable to remove finalizer")
 			// Return error to retry finalizer removal
 			return fmt.Errorf("failed to remove finalizer: %w", err)
@@ -597,6 +1424,74 @@ func (r *ApplicationReconciler) reconcileDeletion(
 	return nil // Return nil error on success
 }
 
+// cleanupLabeledResources removes the resources carrying the application label of a deleted
+// Application. Garbage collection normally takes care of them, but an orphan deletion strips the
+// owner references instead, leaving resources that would block a new Application with the same
+// name. Resources controlled by the Application or by nothing are deleted; resources controlled by
+// something else only lose the label.
+func (r *ApplicationReconciler) cleanupLabeledResources(
+	ctx context.Context,
+	l logr.Logger,
+	app *workloadv1alpha1.Application,
+) error {
+	lists := []client.ObjectList{
+		&servingv1.ServiceList{},
+		&servingv1beta1.DomainMappingList{},
+		&policyv1.PodDisruptionBudgetList{},
+	}
+	if _, err := r.RESTMapper().RESTMapping(serviceMonitorGVK.GroupKind(), serviceMonitorGVK.Version); err == nil {
+		smList := &unstructured.UnstructuredList{}
+		smList.SetGroupVersionKind(serviceMonitorGVK.GroupVersion().WithKind(serviceMonitorGVK.Kind + "List"))
+		lists = append(lists, smList)
+	} else if !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to discover ServiceMonitor CRD: %w", err)
+	}
+	return cleanup.OwnedResources(logf.IntoContext(ctx, l), r.Client, app, cleanup.Selector{
+		Namespace:  app.Namespace,
+		LabelKey:   workloadv1alpha1.ApplicationLabel,
+		LabelValue: app.Name,
+		Orphans:    true,
+		Unlabel:    true,
+	}, lists...)
+}
+
+// updateFinalizer adds or removes the Application finalizer with change, re-fetching the
+// Application and retrying when the update conflicts with a concurrent change.
+func (r *ApplicationReconciler) updateFinalizer(
+	ctx context.Context,
+	app *workloadv1alpha1.Application,
+	change func(client.Object, string) bool,
+) error {
+	refetch := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(app), app); err != nil {
+				return err
+			}
+		}
+		refetch = true
+		if !change(app, workloadv1alpha1.ApplicationFinalizer) {
+			return nil
+		}
+		return r.Update(ctx, app)
+	})
+}
+
+// configRolloutPredicate selects the ConfigMaps and Secrets carrying the config rollout label. An
+// update removing the label is selected too, so the Applications using them roll out without it.
+var configRolloutPredicate = predicate.Funcs{
+	CreateFunc:  func(e event.CreateEvent) bool { return rollsOutOnChange(e.Object) },
+	DeleteFunc:  func(e event.DeleteEvent) bool { return rollsOutOnChange(e.Object) },
+	GenericFunc: func(e event.GenericEvent) bool { return rollsOutOnChange(e.Object) },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return rollsOutOnChange(e.ObjectOld) || rollsOutOnChange(e.ObjectNew)
+	},
+}
+
+func rollsOutOnChange(obj client.Object) bool {
+	return obj.GetLabels()[workloadv1alpha1.ConfigRolloutLabel] == "true"
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Define a predicate to filter resources based on the application label.
@@ -609,6 +1504,11 @@ func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return exists
 	})
 
+	revisionLifecyclePredicate := predicate.Funcs{
+		UpdateFunc: func(event.UpdateEvent) bool { return false },
+	}
+
+	workers := max(mgr.GetControllerOptions().MaxConcurrentReconciles, 1)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&workloadv1alpha1.Application{}).
 		// Owns Knative Service - Reconcile Application if owned Service changes
@@ -620,6 +1520,37 @@ func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		).
 		// Owns DomainMapping - Reconcile Application if owned DomainMapping changes
 		Owns(&servingv1beta1.DomainMapping{}, builder.WithPredicates(applicationLabelPredicate)). // Watch DomainMapping too
+		Owns(&policyv1.PodDisruptionBudget{}, builder.WithPredicates(applicationLabelPredicate)).
+		// Revisions are owned by the Knative Configuration, not the Application, so map them back
+		// through the application label. Only creations and deletions (e.g. revision GC) matter for
+		// the recorded revision; updates are ignored to avoid reconcile storms, and the workqueue
+		// dedupes bursts of events for the same Application.
+		Watches(
+			&servingv1.Revision{},
+			handler.EnqueueRequestsFromMapFunc(r.revisionToApplication),
+			builder.WithPredicates(applicationLabelPredicate, revisionLifecyclePredicate),
+		).
+		// Suspending or resuming a workspace scales its Applications.
+		Watches(
+			&tenancyv1alpha1.Workspace{},
+			handler.EnqueueRequestsFromMapFunc(r.workspaceToApplications),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// ConfigMaps and Secrets opted in through the config rollout label roll out the Applications
+		// referencing them. Only their metadata is watched.
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.configToApplications("ConfigMap")),
+			builder.OnlyMetadata,
+			builder.WithPredicates(configRolloutPredicate),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.configToApplications("Secret")),
+			builder.OnlyMetadata,
+			builder.WithPredicates(configRolloutPredicate),
+		).
 		Named("workload-application").
-		Complete(r)
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		Complete(controllermetrics.Instrument("workload-application", workers, r))
 }
@@ -17,22 +17,65 @@ limitations under the License.
 package workload
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
 	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/yamlutil"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/utils/ptr"
+	"knative.dev/networking/pkg/apis/networking"
+	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/serving"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const serviceMonitorCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: servicemonitors.monitoring.coreos.com
+spec:
+  group: monitoring.coreos.com
+  names:
+    kind: ServiceMonitor
+    listKind: ServiceMonitorList
+    plural: servicemonitors
+    singular: servicemonitor
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+`
+
 var _ = Describe("Application Controller", func() {
 	const (
 		AppName      = "test-app"
@@ -131,6 +174,92 @@ var _ = Describe("Application Controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 
+		It("Should set the rollout duration annotation from the spec", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(serving.RolloutDurationKey, "5m0s"))
+
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.RolloutDuration = &metav1.Duration{Duration: 90 * time.Second}
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(serving.RolloutDurationKey, "1m30s"))
+		})
+
+		It("Should apply the termination grace period as the revision timeout", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Spec.Template.Spec.TimeoutSeconds).To(BeNil())
+
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.TerminationGracePeriodSeconds = ptr.To[int64](45)
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Spec.Template.Spec.TimeoutSeconds).To(HaveValue(BeEquivalentTo(45)))
+		})
+
+		It("Should propagate enableServiceLinks to the Knative Service", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Spec.Template.Spec.EnableServiceLinks).To(BeNil())
+
+			for _, enabled := range []bool{true, false} {
+				Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+				app.Spec.EnableServiceLinks = ptr.To(enabled)
+				Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+				_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+				Expect(ksvc.Spec.Template.Spec.EnableServiceLinks).To(HaveValue(Equal(enabled)))
+			}
+		})
+
+		It("Should log the changed fields at verbosity 1 when it updates the Knative Service", func() {
+			var logs []string
+			logger := funcr.New(func(prefix, args string) {
+				logs = append(logs, args)
+			}, funcr.Options{Verbosity: 1})
+
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.RolloutDuration = &metav1.Duration{Duration: 2 * time.Minute}
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(logf.IntoContext(ctx, logger), ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logs).To(ContainElement(SatisfyAll(
+				ContainSubstring("Knative Service changes"),
+				ContainSubstring(serving.RolloutDurationKey),
+				ContainSubstring("5m0s"),
+				ContainSubstring("2m0s"),
+			)))
+		})
+
+		It("Should report the traffic split of the Knative Route", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			ksvc.Status.ObservedGeneration = ksvc.Generation
+			ksvc.Status.Traffic = []servingv1.TrafficTarget{
+				{RevisionName: AppName + "-00002", Percent: ptr.To[int64](90), LatestRevision: ptr.To(true)},
+				{RevisionName: AppName + "-00001", Percent: ptr.To[int64](10), Tag: "previous"},
+			}
+			Expect(k8sClient.Status().Update(ctx, ksvc)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			Expect(app.Status.Traffic).To(Equal([]workloadv1alpha1.TrafficStatus{
+				{RevisionName: AppName + "-00002", Percent: 90, LatestRevision: true},
+				{RevisionName: AppName + "-00001", Percent: 10, Tag: "previous"},
+			}))
+		})
+
 		It("Should not create a DomainMapping if domain is not specified", func() {
 			dmKey := types.NamespacedName{Name: AppDomain, Namespace: AppNamespace} // Use expected domain name
 			Consistently(func(g Gomega) {
@@ -225,17 +354,178 @@ var _ = Describe("Application Controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 
+		It("Should redirect HTTP to HTTPS by default when TLS is enabled", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(networking.HTTPProtocolAnnotationKey, string(netv1alpha1.HTTPOptionRedirected)))
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(networking.DisableExternalDomainTLSAnnotationKey, "false"))
+
+			dm := &servingv1beta1.DomainMapping{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: AppDomain, Namespace: AppNamespace}, dm)).Should(Succeed())
+			Expect(dm.Annotations).To(HaveKeyWithValue(networking.HTTPProtocolAnnotationKey, string(netv1alpha1.HTTPOptionRedirected)))
+			Expect(dm.Annotations).To(HaveKeyWithValue(networking.DisableExternalDomainTLSAnnotationKey, "false"))
+		})
+
+		It("Should keep TLS but serve plain HTTP when the redirect is disabled", func() {
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.TLSRedirect = ptr.To(false)
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(networking.HTTPProtocolAnnotationKey, string(netv1alpha1.HTTPOptionEnabled)))
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(networking.DisableExternalDomainTLSAnnotationKey, "false"))
+
+			dm := &servingv1beta1.DomainMapping{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: AppDomain, Namespace: AppNamespace}, dm)).Should(Succeed())
+			Expect(dm.Annotations).To(HaveKeyWithValue(networking.HTTPProtocolAnnotationKey, string(netv1alpha1.HTTPOptionEnabled)))
+			Expect(dm.Annotations).To(HaveKeyWithValue(networking.DisableExternalDomainTLSAnnotationKey, "false"))
+		})
+
+		It("Should select the ingress class on the Knative Service and DomainMapping", func() {
+			const class = "kourier.ingress.networking.knative.dev"
+			dmKey := types.NamespacedName{Name: AppDomain, Namespace: AppNamespace}
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.IngressClass = class
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Annotations).To(HaveKeyWithValue(networking.IngressClassAnnotationKey, class))
+			dm := &servingv1beta1.DomainMapping{}
+			Expect(k8sClient.Get(ctx, dmKey, dm)).Should(Succeed())
+			Expect(dm.Annotations).To(HaveKeyWithValue(networking.IngressClassAnnotationKey, class))
+
+			By("falling back to the cluster default once unset")
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.IngressClass = ""
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Annotations).NotTo(HaveKey(networking.IngressClassAnnotationKey))
+			Expect(k8sClient.Get(ctx, dmKey, dm)).Should(Succeed())
+			Expect(dm.Annotations).NotTo(HaveKey(networking.IngressClassAnnotationKey))
+		})
+
+		It("Should only become Ready once the Knative Service and the DomainMappings are ready", func() {
+			readyCondition := func(app *workloadv1alpha1.Application) *metav1.Condition {
+				Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+				return app.Status.GetCondition(workloadv1alpha1.ReadyConditionType)
+			}
+			Expect(readyCondition(app).Reason).To(Equal(workloadv1alpha1.KnativeServiceNotReadyReason))
+
+			By("marking the Knative Service ready")
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			ksvc.Status.ObservedGeneration = ksvc.Generation
+			ksvc.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+			Expect(k8sClient.Status().Update(ctx, ksvc)).Should(Succeed())
+			result, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			cond := readyCondition(app)
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(workloadv1alpha1.DomainMappingNotReadyReason))
+			Expect(cond.Message).To(ContainSubstring(AppDomain))
+			Expect(app.Status.ConditionIsTrue(workloadv1alpha1.KnativeServiceReadyConditionType)).To(BeTrue())
+
+			By("marking the DomainMapping ready")
+			dm := &servingv1beta1.DomainMapping{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: AppDomain, Namespace: AppNamespace}, dm)).Should(Succeed())
+			dm.Status.ObservedGeneration = dm.Generation
+			dm.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+			Expect(k8sClient.Status().Update(ctx, dm)).Should(Succeed())
+			result, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsZero()).To(BeTrue())
+
+			cond = readyCondition(app)
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(app.Status.ConditionIsTrue(workloadv1alpha1.DomainMappingReadyConditionType)).To(BeTrue())
+		})
+
+		It("Should not write the Knative Service or DomainMapping once the redirect is set", func() {
+			watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			var writes []string
+			recordWrite := func(obj client.Object) {
+				switch obj.(type) {
+				case *servingv1.Service, *servingv1beta1.DomainMapping:
+					writes = append(writes, fmt.Sprintf("%T %s", obj, obj.GetName()))
+				}
+			}
+			cr.Client = interceptor.NewClient(watchClient, interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					recordWrite(obj)
+					return c.Update(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					recordWrite(obj)
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writes).To(BeEmpty())
+		})
+
+		It("Should drop the URL of a removed domain in the same reconcile", func() {
+			domainURL := "https://" + AppDomain
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			Expect(app.Status.URLs).To(ContainElement(domainURL))
+
+			By("replacing the domain with one whose DomainMapping belongs to another application")
+			const takenDomain = "taken.example.com"
+			taken := &servingv1beta1.DomainMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      takenDomain,
+					Namespace: AppNamespace,
+					Labels:    map[string]string{workloadv1alpha1.ApplicationLabel: "other-app"},
+				},
+				Spec: servingv1beta1.DomainMappingSpec{
+					Ref: duckv1.KReference{Kind: "Service", Name: "other-app", APIVersion: servingv1.SchemeGroupVersion.String()},
+				},
+			}
+			Expect(k8sClient.Create(ctx, taken)).Should(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, taken) })
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.Domains = []string{takenDomain}
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).To(HaveOccurred())
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			Expect(app.Status.URLs).NotTo(ContainElement(domainURL))
+
+			By("removing every domain")
+			app.Spec.Domains = nil
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			Expect(app.Status.URLs).NotTo(ContainElement(HavePrefix("https://" + takenDomain)))
+			Expect(app.Status.URLs).NotTo(ContainElement(domainURL))
+		})
 	})
 
-	Context("When deleting an Application", func() {
+	Context("When reconciling an Application with a hostname", func() {
+		const platformDomain = "apps.example.com"
+		const hostnameDomain = "api." + platformDomain
 		var app *workloadv1alpha1.Application
-		var cr ApplicationReconciler // Declare cr here
+		var cr ApplicationReconciler
 
 		BeforeEach(func() {
-			// Initialize cr here
 			cr = ApplicationReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				PlatformDomain: platformDomain,
 			}
 			app = &workloadv1alpha1.Application{
 				ObjectMeta: metav1.ObjectMeta{
@@ -248,7 +538,8 @@ var _ = Describe("Application Controller", func() {
 							Image: AppImage,
 						},
 					},
-					Scale: workloadv1alpha1.Scale{ // Removed pointer
+					Hostname: "api",
+					Scale: workloadv1alpha1.Scale{
 						MinReplicas: ptr.To[int32](1),
 						MaxReplicas: ptr.To[int32](1),
 					},
@@ -256,62 +547,140 @@ var _ = Describe("Application Controller", func() {
 					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
 				},
 			}
-			err := k8sClient.Create(ctx, app)
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			cr.PlatformDomain = platformDomain
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
 			Expect(err).NotTo(HaveOccurred())
-			// Use context with logger
-			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+			dm := &servingv1beta1.DomainMapping{ObjectMeta: metav1.ObjectMeta{Name: hostnameDomain, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, dm)
+		})
+
+		It("Should map the hostname under the platform domain", func() {
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
 			Expect(err).NotTo(HaveOccurred())
-			// Use context with logger
 			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
 			Expect(err).NotTo(HaveOccurred())
-			// Ensure the app exists before deleting
-			Eventually(func() error {
-				return k8sClient.Get(ctx, appKey, &workloadv1alpha1.Application{})
-			}, timeout, interval).Should(Succeed())
 
-			// Initiate deletion
-			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
-			// Use context with logger
-			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
-			Expect(err).NotTo(HaveOccurred())
+			dm := &servingv1beta1.DomainMapping{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hostnameDomain, Namespace: AppNamespace}, dm)).To(Succeed())
+			Expect(dm.Labels).To(HaveKeyWithValue(workloadv1alpha1.ApplicationLabel, AppName))
+			Expect(dm.Spec.Ref.Name).To(Equal(AppName))
 
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(app.Status.URLs).To(ContainElement("https://" + hostnameDomain))
 		})
 
-		// No AfterEach needed as the test verifies deletion
+		It("Should fail the DomainMapping condition when no platform domain is configured", func() {
+			cr.PlatformDomain = ""
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).To(HaveOccurred())
 
-		It("Should remove the finalizer", func() {
-			Eventually(func(g Gomega) {
-				fetchedApp := &workloadv1alpha1.Application{}
-				err := k8sClient.Get(ctx, appKey, fetchedApp)
-				// Expect the app to be gone eventually after finalizer removal
-				g.Expect(apierrors.IsNotFound(err)).Should(BeTrue())
-			}, timeout, interval).Should(Succeed())
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			cond := app.Status.GetCondition(workloadv1alpha1.DomainMappingReadyConditionType)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(workloadv1alpha1.DomainMappingCreationFailedReason))
 		})
 	})
 
-	Context("When reconciling an Application that does not exist", func() {
-		It("Should return nil and not error", func() {
-			reconciler := &ApplicationReconciler{
+	Context("When reconciling an Application with a trust bundle", func() {
+		const trustBundle = "corp-ca"
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
 			}
-			req := ctrl.Request{
-				NamespacedName: types.NamespacedName{Name: "non-existent-app", Namespace: AppNamespace},
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration:      &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:            ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					TrustBundleConfigMap: trustBundle,
+				},
 			}
-			// Use context with logger
-			result, err := reconciler.Reconcile(ctx, req)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(result).Should(Equal(ctrl.Result{}))
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should mount the trust bundle and set SSL_CERT_FILE", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			Eventually(func(g Gomega) {
+				ksvc := &servingv1.Service{}
+				g.Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).Should(Succeed())
+
+				podSpec := ksvc.Spec.Template.Spec.PodSpec
+				g.Expect(podSpec.Volumes).To(HaveLen(1))
+				g.Expect(podSpec.Volumes[0].Name).To(Equal(workloadv1alpha1.TrustBundleVolumeName))
+				g.Expect(podSpec.Volumes[0].Projected).NotTo(BeNil())
+				g.Expect(podSpec.Volumes[0].Projected.Sources[0].ConfigMap.Name).To(Equal(trustBundle))
+
+				container := podSpec.Containers[0]
+				g.Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      workloadv1alpha1.TrustBundleVolumeName,
+					MountPath: workloadv1alpha1.TrustBundleMountPath,
+					ReadOnly:  true,
+				}))
+				g.Expect(container.Env).To(ContainElement(corev1.EnvVar{
+					Name:  "SSL_CERT_FILE",
+					Value: workloadv1alpha1.TrustBundleMountPath + "/" + workloadv1alpha1.TrustBundleKey,
+				}))
+			}, timeout, interval).Should(Succeed())
+
+			By("leaving the Application spec untouched")
+			fetchedApp := &workloadv1alpha1.Application{}
+			Expect(k8sClient.Get(ctx, appKey, fetchedApp)).To(Succeed())
+			Expect(fetchedApp.Spec.Containers[0].VolumeMounts).To(BeEmpty())
+			Expect(fetchedApp.Spec.Containers[0].Env).To(BeEmpty())
 		})
 	})
 
-	// Add more tests for specific annotation settings (TLS, scaling), status updates on errors, etc.
-	Context("When reconciling Application with specific scaling annotations", func() {
+	Context("When reconciling an Application exposing pod metadata", func() {
 		var app *workloadv1alpha1.Application
-		var cr ApplicationReconciler // Declare cr here
+		var cr ApplicationReconciler
 
 		BeforeEach(func() {
-			// Initialize cr here
 			cr = ApplicationReconciler{
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
@@ -325,32 +694,27 @@ var _ = Describe("Application Controller", func() {
 					Containers: []corev1.Container{
 						{
 							Image: AppImage,
+							Env:   []corev1.EnvVar{{Name: "POD_NAME", Value: "custom"}},
 						},
 					},
-					Scale: workloadv1alpha1.Scale{ // Removed pointer
-						MinReplicas:                 ptr.To[int32](2),
-						MaxReplicas:                 ptr.To[int32](5),
-						Metric:                      workloadv1alpha1.MetricCPU,
-						Target:                      ptr.To[int32](80), // Target is deprecated but let's test it
-						TargetUtilizationPercentage: ptr.To[int32](75),
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
 					},
-					EnableTLS:       ptr.To(false), // Test disabling TLS
-					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					RolloutDuration:   &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:         ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					ExposePodMetadata: true,
 				},
 			}
-			err := k8sClient.Create(ctx, app)
-			Expect(err).NotTo(HaveOccurred())
-			// Use context with logger
-			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
 			Expect(err).NotTo(HaveOccurred())
-			// Use context with logger
 			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
 		AfterEach(func() {
 			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
-			// Use context with logger
 			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
@@ -361,14 +725,1373 @@ var _ = Describe("Application Controller", func() {
 			_ = k8sClient.Delete(ctx, ksvc)
 		})
 
-		It("Should set the correct annotations on the Knative Service", func() {
+		It("Should inject the downward API env without overriding user values", func() {
 			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
 			Eventually(func(g Gomega) {
 				ksvc := &servingv1.Service{}
 				g.Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).Should(Succeed())
-				g.Expect(ksvc.Annotations).To(HaveKeyWithValue("networking.knative.dev/disable-external-domain-tls", "true")) // TLS disabled
+
+				env := ksvc.Spec.Template.Spec.Containers[0].Env
+				g.Expect(env).To(HaveLen(3))
+				g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "POD_NAME", Value: "custom"}))
+				g.Expect(env).To(ContainElement(corev1.EnvVar{
+					Name:      "POD_NAMESPACE",
+					ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+				}))
+				g.Expect(env).To(ContainElement(corev1.EnvVar{
+					Name:      "POD_IP",
+					ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+				}))
 			}, timeout, interval).Should(Succeed())
 		})
 	})
 
+	Context("When reconciling an Application with labels selected for its revisions", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client:                k8sClient,
+				Scheme:                k8sClient.Scheme(),
+				RevisionLabelPrefixes: []string{"team", "cost-center", workloadv1alpha1.ApplicationLabel},
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+					Labels: map[string]string{
+						"team":        "payments",
+						"cost-center": "cc-42",
+						"owner":       "alice",
+					},
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should only copy the allowlisted labels onto the revision template", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).To(Succeed())
+			labels := ksvc.Spec.Template.Labels
+			Expect(labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(labels).To(HaveKeyWithValue("cost-center", "cc-42"))
+			Expect(labels).NotTo(HaveKey("owner"))
+			Expect(labels).To(HaveKeyWithValue(workloadv1alpha1.ApplicationLabel, AppName))
+
+			By("dropping a label removed from the Application and keeping the controller ones")
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			delete(app.Labels, "cost-center")
+			app.Labels[workloadv1alpha1.ApplicationLabel] = "other"
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).To(Succeed())
+			labels = ksvc.Spec.Template.Labels
+			Expect(labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(labels).NotTo(HaveKey("cost-center"))
+			Expect(labels).To(HaveKeyWithValue(workloadv1alpha1.ApplicationLabel, AppName))
+		})
+
+		It("Should rename the revision when relabeling an Application with a revision name prefix", func() {
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			app.Spec.RevisionNamePrefix = "v"
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).To(Succeed())
+			before := ksvc.Spec.Template.Name
+			Expect(before).To(HavePrefix(fmt.Sprintf("%s-v%d-", AppName, app.Generation)))
+
+			By("changing only a propagated label, which keeps the generation")
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			generation := app.Generation
+			app.Labels["team"] = "billing"
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			Expect(app.Generation).To(Equal(generation))
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Labels).To(HaveKeyWithValue("team", "billing"))
+			Expect(ksvc.Spec.Template.Name).To(HavePrefix(fmt.Sprintf("%s-v%d-", AppName, generation)))
+			Expect(ksvc.Spec.Template.Name).NotTo(Equal(before))
+
+			By("keeping the name while the labels do not change")
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			renamed := ksvc.Spec.Template.Name
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Name).To(Equal(renamed))
+		})
+	})
+
+	Context("When reconciling an Application rolling out on config changes", func() {
+		const appName = "config-rollout-app"
+		var app *workloadv1alpha1.Application
+		var watched, unwatched *corev1.ConfigMap
+		var cr ApplicationReconciler
+		key := types.NamespacedName{Name: appName, Namespace: AppNamespace}
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			watched = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "watched-config",
+					Namespace: AppNamespace,
+					Labels:    map[string]string{workloadv1alpha1.ConfigRolloutLabel: "true"},
+				},
+				Data: map[string]string{"LOG_LEVEL": "info"},
+			}
+			Expect(k8sClient.Create(ctx, watched)).To(Succeed())
+			unwatched = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "unwatched-config", Namespace: AppNamespace},
+				Data:       map[string]string{"REGION": "eu"},
+			}
+			Expect(k8sClient.Create(ctx, unwatched)).To(Succeed())
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      appName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+							EnvFrom: []corev1.EnvFromSource{{
+								ConfigMapRef: &corev1.ConfigMapEnvSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: watched.Name},
+								},
+							}},
+							Env: []corev1.EnvVar{{
+								Name: "REGION",
+								ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: unwatched.Name},
+									Key:                  "REGION",
+								}},
+							}},
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration:       &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:             ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					RolloutOnConfigChange: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: appName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+			Expect(k8sClient.Delete(ctx, watched)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, unwatched)).To(Succeed())
+		})
+
+		configHash := func() string {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, key, ksvc)).To(Succeed())
+			return ksvc.Spec.Template.Annotations[workloadv1alpha1.ConfigHashAnnotation]
+		}
+
+		It("Should enqueue the Application and bump the hash when a watched ConfigMap changes", func() {
+			initial := configHash()
+			Expect(initial).NotTo(BeEmpty())
+
+			watched.Data["LOG_LEVEL"] = "debug"
+			Expect(k8sClient.Update(ctx, watched)).To(Succeed())
+			Expect(cr.configToApplications("ConfigMap")(ctx, watched)).To(ConsistOf(ctrl.Request{NamespacedName: key}))
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configHash()).NotTo(Equal(initial))
+		})
+
+		It("Should render the same template for diffs as the reconciler applies", func() {
+			Expect(k8sClient.Get(ctx, key, app)).To(Succeed())
+			live := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, key, live)).To(Succeed())
+			opts, err := NewRevisionOptions(ctx, k8sClient, app, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(opts.ConfigHash).To(Equal(configHash()))
+			desired, err := DesiredKnativeService(app, live, k8sClient.Scheme(), opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.Spec.Template).To(Equal(live.Spec.Template))
+		})
+
+		It("Should rename a prefixed revision when a watched ConfigMap changes", func() {
+			Expect(k8sClient.Get(ctx, key, app)).To(Succeed())
+			app.Spec.RevisionNamePrefix = "v"
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, key, ksvc)).To(Succeed())
+			initial := ksvc.Spec.Template.Name
+			Expect(initial).To(HavePrefix(appName + "-v"))
+
+			watched.Data["LOG_LEVEL"] = "debug"
+			Expect(k8sClient.Update(ctx, watched)).To(Succeed())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Name).NotTo(Equal(initial))
+			Expect(len(ksvc.Spec.Template.Name)).To(Equal(len(initial)))
+		})
+
+		It("Should ignore ConfigMaps without the rollout label", func() {
+			initial := configHash()
+			unwatched.Data["REGION"] = "us"
+			Expect(k8sClient.Update(ctx, unwatched)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configHash()).To(Equal(initial))
+		})
+
+		It("Should enqueue the Applications when the rollout label is removed", func() {
+			initial := configHash()
+			unlabeled := watched.DeepCopy()
+			delete(unlabeled.Labels, workloadv1alpha1.ConfigRolloutLabel)
+			Expect(configRolloutPredicate.Update(event.UpdateEvent{ObjectOld: watched, ObjectNew: unlabeled})).To(BeTrue())
+			Expect(configRolloutPredicate.Update(event.UpdateEvent{ObjectOld: unwatched, ObjectNew: unwatched})).To(BeFalse())
+
+			Expect(k8sClient.Update(ctx, unlabeled)).To(Succeed())
+			Expect(cr.configToApplications("ConfigMap")(ctx, unlabeled)).To(ConsistOf(ctrl.Request{NamespacedName: key}))
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configHash()).NotTo(Equal(initial))
+		})
+
+		It("Should drop the hash once the Application opts out", func() {
+			Expect(k8sClient.Get(ctx, key, app)).To(Succeed())
+			app.Spec.RolloutOnConfigChange = false
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			Expect(cr.configToApplications("ConfigMap")(ctx, watched)).To(BeEmpty())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configHash()).To(BeEmpty())
+		})
+	})
+
+	Context("When reconciling an Application with a sidecar referencing the application port", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: AppImage,
+							Ports: []corev1.ContainerPort{
+								{Name: "admin", ContainerPort: 9091},
+								{Name: "http1", ContainerPort: 9090},
+							},
+						},
+						{
+							Name:  "proxy",
+							Image: AppImage,
+							Env: []corev1.EnvVar{
+								{Name: "UPSTREAM", Value: "http://127.0.0.1:$(FCP_APP_PORT)"},
+								{Name: "OTHER", Value: "$(UNRELATED)"},
+							},
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should resolve the application port in the sidecar env", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			Eventually(func(g Gomega) {
+				ksvc := &servingv1.Service{}
+				g.Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).Should(Succeed())
+
+				g.Expect(ksvc.Spec.Template.Spec.Containers).To(HaveLen(2))
+				env := ksvc.Spec.Template.Spec.Containers[1].Env
+				g.Expect(env).To(ConsistOf(
+					corev1.EnvVar{Name: "UPSTREAM", Value: "http://127.0.0.1:9090"},
+					corev1.EnvVar{Name: "OTHER", Value: "$(UNRELATED)"},
+				))
+			}, timeout, interval).Should(Succeed())
+
+			By("leaving the Application spec untouched")
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(app.Spec.Containers[1].Env[0].Value).To(Equal("http://127.0.0.1:$(FCP_APP_PORT)"))
+		})
+
+		It("Should only pass the serving port to Knative", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			Eventually(func(g Gomega) {
+				ksvc := &servingv1.Service{}
+				g.Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).Should(Succeed())
+				g.Expect(ksvc.Spec.Template.Spec.Containers[0].Ports).To(ConsistOf(
+					corev1.ContainerPort{Name: "http1", ContainerPort: 9090, Protocol: corev1.ProtocolTCP},
+				))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	Context("When reconciling an Application with topology spread constraints", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](3),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+						},
+						{
+							MaxSkew:           2,
+							TopologyKey:       "kubernetes.io/hostname",
+							WhenUnsatisfiable: corev1.DoNotSchedule,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"tier": "web"},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should propagate the constraints, selecting the application pods by default", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			Eventually(func(g Gomega) {
+				ksvc := &servingv1.Service{}
+				g.Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).Should(Succeed())
+
+				constraints := ksvc.Spec.Template.Spec.TopologySpreadConstraints
+				g.Expect(constraints).To(HaveLen(2))
+				g.Expect(constraints[0].TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+				g.Expect(constraints[0].LabelSelector).To(Equal(&metav1.LabelSelector{
+					MatchLabels: map[string]string{serving.ServiceLabelKey: AppName},
+				}))
+				g.Expect(constraints[1].WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+				g.Expect(constraints[1].LabelSelector.MatchLabels).To(Equal(map[string]string{"tier": "web"}))
+			}, timeout, interval).Should(Succeed())
+
+			By("leaving the Application spec untouched")
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(app.Spec.TopologySpreadConstraints[0].LabelSelector).To(BeNil())
+		})
+	})
+
+	Context("When reconciling an Application with a disruption budget", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](2),
+						MaxReplicas: ptr.To[int32](3),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					DisruptionBudget: &workloadv1alpha1.DisruptionBudget{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+			pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, pdb)
+		})
+
+		It("Should create a PodDisruptionBudget selecting the revision pods", func() {
+			pdb := &policyv1.PodDisruptionBudget{}
+			Expect(k8sClient.Get(ctx, appKey, pdb)).To(Succeed())
+			Expect(pdb.Spec.Selector).To(Equal(&metav1.LabelSelector{
+				MatchLabels: map[string]string{serving.ServiceLabelKey: AppName},
+			}))
+			Expect(pdb.Spec.MinAvailable).To(Equal(ptr.To(intstr.FromInt32(1))))
+			Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+			Expect(pdb.Labels).To(HaveKeyWithValue(workloadv1alpha1.ApplicationLabel, AppName))
+			Expect(metav1.IsControlledBy(pdb, app)).To(BeTrue())
+		})
+
+		It("Should update the budget and remove it once unset", func() {
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			app.Spec.DisruptionBudget = &workloadv1alpha1.DisruptionBudget{
+				MaxUnavailable: ptr.To(intstr.FromString("50%")),
+			}
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			pdb := &policyv1.PodDisruptionBudget{}
+			Expect(k8sClient.Get(ctx, appKey, pdb)).To(Succeed())
+			Expect(pdb.Spec.MinAvailable).To(BeNil())
+			Expect(pdb.Spec.MaxUnavailable).To(Equal(ptr.To(intstr.FromString("50%"))))
+
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			app.Spec.DisruptionBudget = nil
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(apierrors.IsNotFound(k8sClient.Get(ctx, appKey, pdb))).To(BeTrue())
+		})
+	})
+
+	Context("When reconciling an Application with a revision name prefix", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration:    &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:          ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					RevisionNamePrefix: "v",
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should name the revision after the Application generation", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Name).To(Equal(AppName + "-v1"))
+
+			By("bumping the revision name when the spec changes")
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			app.Spec.Containers[0].Image = AppImage + "-next"
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Name).To(Equal(AppName + "-v2"))
+		})
+	})
+
+	Context("When reconciling an Application of a suspended workspace", func() {
+		var app *workloadv1alpha1.Application
+		var workspace *tenancyv1alpha1.Workspace
+		var cr ApplicationReconciler
+
+		setSuspended := func(suspended bool) {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace)).To(Succeed())
+			workspace.Spec.Suspended = suspended
+			Expect(k8sClient.Update(ctx, workspace)).To(Succeed())
+			Expect(cr.workspaceToApplications(ctx, workspace)).To(ContainElement(ctrl.Request{NamespacedName: appKey}))
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			// The workspace of an Application is the Workspace named after its namespace.
+			workspace = &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: AppNamespace},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:      tenancyv1alpha1.WorkspaceTypeOrganization,
+					Owners:    []corev1.ObjectReference{{Kind: "User", Name: "owner"}},
+					Suspended: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](2),
+						MaxReplicas: ptr.To[int32](3),
+					},
+					RolloutDuration:    &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:          ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					RevisionNamePrefix: "v",
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+			Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+		})
+
+		It("Should scale to zero and hide the service until the workspace is resumed", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).To(Succeed())
+			Expect(ksvc.Labels).To(HaveKeyWithValue(networking.VisibilityLabelKey, serving.VisibilityClusterLocal))
+			Expect(ksvc.Spec.Template.Labels).NotTo(HaveKey(networking.VisibilityLabelKey))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.MinScaleAnnotationKey, "0"))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.InitialScaleAnnotationKey, "0"))
+			suspendedRevision := ksvc.Spec.Template.Name
+			Expect(suspendedRevision).To(HavePrefix(AppName + "-v1-"))
+
+			By("ignoring a suspended label the tenant removes")
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			app.Labels = map[string]string{tenancyv1alpha1.WorkspaceSuspendedLabel: "false"}
+			Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.MinScaleAnnotationKey, "0"))
+
+			By("resuming the workspace")
+			setSuspended(false)
+			Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).To(Succeed())
+			Expect(ksvc.Labels).NotTo(HaveKey(networking.VisibilityLabelKey))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.MinScaleAnnotationKey, "2"))
+			Expect(ksvc.Spec.Template.Name).To(Equal(AppName + "-v1"))
+
+			By("suspending it again under a new revision name")
+			setSuspended(true)
+			Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.MinScaleAnnotationKey, "0"))
+			Expect(ksvc.Spec.Template.Name).To(Equal(suspendedRevision))
+		})
+	})
+
+	Context("When a Knative Service with the Application's name already exists", func() {
+		const adoptAppName = "adopt-app"
+		adoptKey := types.NamespacedName{Name: adoptAppName, Namespace: AppNamespace}
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		createExistingService := func(annotations map[string]string) {
+			ksvc := &servingv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        adoptAppName,
+					Namespace:   AppNamespace,
+					Annotations: annotations,
+				},
+				Spec: servingv1.ServiceSpec{
+					ConfigurationSpec: servingv1.ConfigurationSpec{
+						Template: servingv1.RevisionTemplateSpec{
+							Spec: servingv1.RevisionSpec{
+								PodSpec: corev1.PodSpec{
+									Containers: []corev1.Container{{Image: "manual-image:latest"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, ksvc)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      adoptAppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+				},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, adoptKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: adoptAppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should refuse to take over a service without the adopt annotation", func() {
+			createExistingService(nil)
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(workloadv1alpha1.AdoptAnnotation))
+
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, adoptKey, ksvc)).To(Succeed())
+			Expect(ksvc.OwnerReferences).To(BeEmpty())
+			Expect(ksvc.Spec.Template.Spec.Containers[0].Image).To(Equal("manual-image:latest"))
+
+			fetchedApp := &workloadv1alpha1.Application{}
+			Expect(k8sClient.Get(ctx, adoptKey, fetchedApp)).To(Succeed())
+			cond := fetchedApp.Status.GetCondition(workloadv1alpha1.ReadyConditionType)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal(workloadv1alpha1.KnativeServiceAdoptionRefusedReason))
+		})
+
+		It("Should adopt a service annotated for adoption", func() {
+			createExistingService(map[string]string{workloadv1alpha1.AdoptAnnotation: "true"})
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, adoptKey, ksvc)).To(Succeed())
+			Expect(metav1.IsControlledBy(ksvc, app)).To(BeTrue())
+			Expect(ksvc.Spec.Template.Spec.Containers[0].Image).To(Equal(AppImage))
+		})
+
+		It("Should report a service controlled by another controller without erroring", func() {
+			createExistingService(map[string]string{workloadv1alpha1.AdoptAnnotation: "true"})
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, adoptKey, ksvc)).To(Succeed())
+			ksvc.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "example.com/v1",
+				Kind:       "Widget",
+				Name:       "other",
+				UID:        "00000000-0000-0000-0000-000000000001",
+				Controller: ptr.To(true),
+			}}
+			Expect(k8sClient.Update(ctx, ksvc)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+			result, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsZero()).To(BeTrue())
+
+			Expect(k8sClient.Get(ctx, adoptKey, ksvc)).To(Succeed())
+			Expect(ksvc.OwnerReferences).To(HaveLen(1))
+			Expect(ksvc.OwnerReferences[0].Kind).To(Equal("Widget"))
+			Expect(ksvc.Spec.Template.Spec.Containers[0].Image).To(Equal("manual-image:latest"))
+
+			fetchedApp := &workloadv1alpha1.Application{}
+			Expect(k8sClient.Get(ctx, adoptKey, fetchedApp)).To(Succeed())
+			cond := fetchedApp.Status.GetCondition(workloadv1alpha1.ReadyConditionType)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(workloadv1alpha1.KnativeServiceOwnedElsewhereReason))
+			Expect(cond.Message).To(ContainSubstring("owned by another controller; cannot manage"))
+			Expect(cond.Message).To(ContainSubstring("Widget other"))
+		})
+	})
+
+	Context("When reconciling an Application exposing metrics", func() {
+		const metricsAppName = "metrics-app"
+		metricsKey := types.NamespacedName{Name: metricsAppName, Namespace: AppNamespace}
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler
+
+		newMetricsApp := func(serviceMonitor bool) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metricsAppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					Metrics: &workloadv1alpha1.Metrics{
+						Port:           9090,
+						Path:           "/custom-metrics",
+						ServiceMonitor: serviceMonitor,
+					},
+				},
+			}
+		}
+
+		reconcileApp := func() {
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: metricsKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: metricsKey})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: metricsKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, metricsKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: metricsAppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should add the Prometheus scrape annotations to the revision template", func() {
+			app = newMetricsApp(false)
+			reconcileApp()
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, metricsKey, ksvc)).To(Succeed())
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/scrape", "true"))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/port", "9090"))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/path", "/custom-metrics"))
+		})
+
+		It("Should create a ServiceMonitor when the Prometheus Operator CRD is installed", func() {
+			ioStreams := genericiooptions.IOStreams{In: os.Stdin, Out: GinkgoWriter, ErrOut: GinkgoWriter}
+			Expect(yamlutil.ApplyManifestYAML(ctx, k8sClient, serviceMonitorCRD, ioStreams)).To(Succeed())
+			Eventually(func() error {
+				_, err := k8sClient.RESTMapper().RESTMapping(serviceMonitorGVK.GroupKind(), serviceMonitorGVK.Version)
+				return err
+			}, timeout, interval).Should(Succeed())
+
+			app = newMetricsApp(true)
+			reconcileApp()
+			sm := &unstructured.Unstructured{}
+			sm.SetGroupVersionKind(serviceMonitorGVK)
+			Expect(k8sClient.Get(ctx, metricsKey, sm)).To(Succeed())
+			Expect(metav1.IsControlledBy(sm, app)).To(BeTrue())
+			endpoints, found, err := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(endpoints).To(ConsistOf(HaveKeyWithValue("path", "/custom-metrics")))
+		})
+	})
+
+	Context("When deleting an Application", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler // Declare cr here
+
+		BeforeEach(func() {
+			// Initialize cr here
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{ // Removed pointer
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+				},
+			}
+			err := k8sClient.Create(ctx, app)
+			Expect(err).NotTo(HaveOccurred())
+			// Use context with logger
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			// Use context with logger
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			// Ensure the app exists before deleting
+			Eventually(func() error {
+				return k8sClient.Get(ctx, appKey, &workloadv1alpha1.Application{})
+			}, timeout, interval).Should(Succeed())
+
+			// Initiate deletion
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			// Use context with logger
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+		})
+
+		// No AfterEach needed as the test verifies deletion
+
+		It("Should remove the finalizer", func() {
+			Eventually(func(g Gomega) {
+				fetchedApp := &workloadv1alpha1.Application{}
+				err := k8sClient.Get(ctx, appKey, fetchedApp)
+				// Expect the app to be gone eventually after finalizer removal
+				g.Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	Context("When an Application is deleted with the orphan propagation policy", func() {
+		const orphanAppName = "orphan-app"
+		orphanKey := types.NamespacedName{Name: orphanAppName, Namespace: AppNamespace}
+		var cr ApplicationReconciler
+
+		BeforeEach(func() {
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      orphanAppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](2),
+						MaxReplicas: ptr.To[int32](3),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+					DisruptionBudget: &workloadv1alpha1.DisruptionBudget{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: orphanKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: orphanKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			// envtest runs no garbage collector to drop the orphan finalizer.
+			app := &workloadv1alpha1.Application{}
+			if err := k8sClient.Get(ctx, orphanKey, app); err == nil {
+				app.Finalizers = nil
+				Expect(k8sClient.Update(ctx, app)).To(Succeed())
+			}
+			foreign := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "orphan-app-foreign", Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, foreign)
+		})
+
+		It("Should delete the orphaned resources and unlabel the ones controlled elsewhere", func() {
+			// The garbage collector strips the owner references of the dependents of an orphan deletion.
+			for _, obj := range []client.Object{&servingv1.Service{}, &policyv1.PodDisruptionBudget{}} {
+				Expect(k8sClient.Get(ctx, orphanKey, obj)).To(Succeed())
+				obj.SetOwnerReferences(nil)
+				Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+			}
+			foreign := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "orphan-app-foreign",
+					Namespace: AppNamespace,
+					Labels:    map[string]string{workloadv1alpha1.ApplicationLabel: orphanAppName},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "other",
+						UID:        "11111111-2222-3333-4444-555555555555",
+						Controller: ptr.To(true),
+					}},
+				},
+				Spec: policyv1.PodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromInt32(1))},
+			}
+			Expect(k8sClient.Create(ctx, foreign)).To(Succeed())
+
+			app := &workloadv1alpha1.Application{}
+			Expect(k8sClient.Get(ctx, orphanKey, app)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, app, client.PropagationPolicy(metav1.DeletePropagationOrphan))).To(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: orphanKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func(g Gomega) {
+				g.Expect(apierrors.IsNotFound(k8sClient.Get(ctx, orphanKey, &servingv1.Service{}))).To(BeTrue())
+				g.Expect(apierrors.IsNotFound(k8sClient.Get(ctx, orphanKey, &policyv1.PodDisruptionBudget{}))).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(foreign), foreign)).To(Succeed())
+			Expect(foreign.Labels).NotTo(HaveKey(workloadv1alpha1.ApplicationLabel))
+			Expect(k8sClient.Get(ctx, orphanKey, app)).To(Succeed())
+			Expect(app.Finalizers).NotTo(ContainElement(workloadv1alpha1.ApplicationFinalizer))
+		})
+	})
+
+	Context("When a finalizer update conflicts with a concurrent change", func() {
+		It("Should retry adding and removing the finalizer", func() {
+			watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			conflict := true
+			conflicted := 0
+			cr := ApplicationReconciler{
+				Client: interceptor.NewClient(watchClient, interceptor.Funcs{
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*workloadv1alpha1.Application); ok && conflict {
+							conflict = false
+							conflicted++
+							return apierrors.NewConflict(workloadv1alpha1.GroupVersion.WithResource("applications").GroupResource(),
+								obj.GetName(), fmt.Errorf("injected conflict"))
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				}),
+				Scheme: k8sClient.Scheme(),
+			}
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{Image: AppImage}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](1),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+					EnableTLS:       ptr.To(workloadv1alpha1.DefaultEnableTLS),
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+
+			By("adding the finalizer")
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, appKey, app)).To(Succeed())
+			Expect(app.Finalizers).To(ContainElement(workloadv1alpha1.ApplicationFinalizer))
+
+			By("removing the finalizer")
+			conflict = true
+			Expect(k8sClient.Delete(ctx, app)).To(Succeed())
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apierrors.IsNotFound(k8sClient.Get(ctx, appKey, app))).To(BeTrue())
+			Expect(conflicted).To(Equal(2))
+		})
+	})
+
+	Context("When reconciling an Application that does not exist", func() {
+		It("Should return nil and not error", func() {
+			reconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: "non-existent-app", Namespace: AppNamespace},
+			}
+			// Use context with logger
+			result, err := reconciler.Reconcile(ctx, req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(Equal(ctrl.Result{}))
+		})
+	})
+
+	Context("When a Knative Revision is created or deleted", func() {
+		It("Should enqueue the owning Application", func() {
+			reconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			rev := &servingv1.Revision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName + "-00001",
+					Namespace: AppNamespace,
+					Labels:    map[string]string{workloadv1alpha1.ApplicationLabel: AppName},
+				},
+			}
+			Expect(reconciler.revisionToApplication(ctx, rev)).To(ConsistOf(ctrl.Request{NamespacedName: appKey}))
+		})
+
+		It("Should ignore revisions without the application label", func() {
+			reconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			rev := &servingv1.Revision{
+				ObjectMeta: metav1.ObjectMeta{Name: "unmanaged-00001", Namespace: AppNamespace},
+			}
+			Expect(reconciler.revisionToApplication(ctx, rev)).To(BeEmpty())
+		})
+	})
+
+	// Add more tests for specific annotation settings (TLS, scaling), status updates on errors, etc.
+	Context("When reconciling Application with specific scaling annotations", func() {
+		var app *workloadv1alpha1.Application
+		var cr ApplicationReconciler // Declare cr here
+
+		BeforeEach(func() {
+			// Initialize cr here
+			cr = ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			app = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      AppName,
+					Namespace: AppNamespace,
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{
+							Image: AppImage,
+						},
+					},
+					Scale: workloadv1alpha1.Scale{ // Removed pointer
+						MinReplicas:                 ptr.To[int32](2),
+						MaxReplicas:                 ptr.To[int32](5),
+						Metric:                      workloadv1alpha1.MetricCPU,
+						Target:                      ptr.To[int32](80), // Target is deprecated but let's test it
+						TargetUtilizationPercentage: ptr.To[int32](75),
+					},
+					EnableTLS:       ptr.To(false), // Test disabling TLS
+					RolloutDuration: &metav1.Duration{Duration: workloadv1alpha1.DefaultRolloutDuration},
+				},
+			}
+			err := k8sClient.Create(ctx, app)
+			Expect(err).NotTo(HaveOccurred())
+			// Use context with logger
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			// Use context with logger
+			_, err = cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, app)).Should(Succeed())
+			// Use context with logger
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, appKey, app)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace}}
+			_ = k8sClient.Delete(ctx, ksvc)
+		})
+
+		It("Should set the correct annotations on the Knative Service", func() {
+			ksvcKey := types.NamespacedName{Name: AppName, Namespace: AppNamespace}
+			Eventually(func(g Gomega) {
+				ksvc := &servingv1.Service{}
+				g.Expect(k8sClient.Get(ctx, ksvcKey, ksvc)).Should(Succeed())
+				g.Expect(ksvc.Annotations).To(HaveKeyWithValue("networking.knative.dev/disable-external-domain-tls", "true")) // TLS disabled
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("Should start new revisions at minReplicas unless an initial scale is set", func() {
+			ksvc := &servingv1.Service{}
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.InitialScaleAnnotationKey, "2"))
+
+			Expect(k8sClient.Get(ctx, appKey, app)).Should(Succeed())
+			app.Spec.Scale.InitialScale = ptr.To[int32](4)
+			Expect(k8sClient.Update(ctx, app)).Should(Succeed())
+			_, err := cr.Reconcile(ctx, ctrl.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, appKey, ksvc)).Should(Succeed())
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.InitialScaleAnnotationKey, "4"))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.MinScaleAnnotationKey, "2"))
+		})
+	})
+
+	Context("When an Application selects a profile", func() {
+		newApp := func(profile workloadv1alpha1.Profile, scale workloadv1alpha1.Scale) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: AppNamespace},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{Image: AppImage}},
+					Scale:      scale,
+					Profile:    profile,
+				},
+			}
+		}
+
+		templateAnnotations := func(app *workloadv1alpha1.Application) map[string]string {
+			ksvc := &servingv1.Service{}
+			mutateKnativeService(app, ksvc, false)
+			return ksvc.Spec.Template.Annotations
+		}
+
+		It("should expand the low-latency profile", func() {
+			annotations := templateAnnotations(newApp(workloadv1alpha1.ProfileLowLatency, workloadv1alpha1.Scale{}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "50"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.WindowAnnotationKey, "30s"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.ScaleDownDelayAnnotationKey, "5m"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.ScaleToZeroPodRetentionPeriodKey, "10m"))
+		})
+
+		It("should expand the batch profile", func() {
+			annotations := templateAnnotations(newApp(workloadv1alpha1.ProfileBatch, workloadv1alpha1.Scale{}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "10"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.WindowAnnotationKey, "120s"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.PanicThresholdPercentageAnnotationKey, "1000"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetBurstCapacityKey, "0"))
+		})
+
+		It("should expand the cost-optimized profile", func() {
+			annotations := templateAnnotations(newApp(workloadv1alpha1.ProfileCostOptimized,
+				workloadv1alpha1.Scale{Metric: workloadv1alpha1.MetricCPU}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "100"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetUtilizationPercentageKey, "90"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.ScaleDownDelayAnnotationKey, "0s"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.ScaleToZeroPodRetentionPeriodKey, "0s"))
+		})
+
+		It("should let explicit Scale fields win over the profile", func() {
+			annotations := templateAnnotations(newApp(workloadv1alpha1.ProfileCostOptimized, workloadv1alpha1.Scale{
+				Target:                      ptr.To[int32](25),
+				TargetUtilizationPercentage: ptr.To[int32](60),
+			}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "25"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetUtilizationPercentageKey, "60"))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.ScaleDownDelayAnnotationKey, "0s"))
+		})
+
+		It("should only apply the default target without a profile target", func() {
+			annotations := templateAnnotations(newApp(workloadv1alpha1.ProfileBatch, workloadv1alpha1.Scale{}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "10"))
+
+			By("keeping an explicit target equal to the default")
+			annotations = templateAnnotations(newApp(workloadv1alpha1.ProfileBatch, workloadv1alpha1.Scale{
+				Target: ptr.To(workloadv1alpha1.DefaultTargetUtilization),
+			}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "80"))
+
+			By("applying the default target without a profile")
+			annotations = templateAnnotations(newApp("", workloadv1alpha1.Scale{}))
+			Expect(annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "80"))
+		})
+
+		It("should drop the settings of a previous profile", func() {
+			app := newApp(workloadv1alpha1.ProfileBatch, workloadv1alpha1.Scale{})
+			ksvc := &servingv1.Service{}
+			mutateKnativeService(app, ksvc, false)
+
+			app.Spec.Profile = workloadv1alpha1.ProfileLowLatency
+			mutateKnativeService(app, ksvc, false)
+			Expect(ksvc.Spec.Template.Annotations).NotTo(HaveKey(autoscaling.TargetBurstCapacityKey))
+			Expect(ksvc.Spec.Template.Annotations).NotTo(HaveKey(autoscaling.PanicThresholdPercentageAnnotationKey))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.WindowAnnotationKey, "30s"))
+
+			app.Spec.Profile = ""
+			mutateKnativeService(app, ksvc, false)
+			Expect(ksvc.Spec.Template.Annotations).NotTo(HaveKey(autoscaling.WindowAnnotationKey))
+			Expect(ksvc.Spec.Template.Annotations).To(HaveKeyWithValue(autoscaling.TargetAnnotationKey, "80"))
+		})
+	})
 })
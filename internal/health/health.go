@@ -0,0 +1,64 @@
+// Package health provides the readiness checks served on the manager probe endpoint.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// cacheSyncTimeout bounds how long a single readiness probe waits for the informers.
+const cacheSyncTimeout = time.Second
+
+// CacheSynced reports ready once every informer of the manager cache has synced.
+func CacheSynced(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informer caches are not synced yet")
+		}
+		return nil
+	}
+}
+
+// CRDsInstalled reports ready once the API server serves every given kind,
+// so a missing or not yet established CRD keeps the manager out of rotation.
+func CRDsInstalled(mapper meta.RESTMapper, gvks ...schema.GroupVersionKind) healthz.Checker {
+	return func(_ *http.Request) error {
+		return missingKinds(mapper, gvks)
+	}
+}
+
+// WaitForCRDs blocks until the API server serves every given kind, logging the missing ones on each
+// attempt. It only returns an error when ctx is cancelled first.
+func WaitForCRDs(ctx context.Context, mapper meta.RESTMapper, interval time.Duration, gvks ...schema.GroupVersionKind) error {
+	log := logf.FromContext(ctx)
+	return wait.PollUntilContextCancel(ctx, interval, true, func(context.Context) (bool, error) {
+		if err := missingKinds(mapper, gvks); err != nil {
+			log.Info("Waiting for required CRDs to be installed, install Knative Serving and the fcp CRDs first",
+				"missing", err.Error())
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func missingKinds(mapper meta.RESTMapper, gvks []schema.GroupVersionKind) error {
+	var errs []error
+	for _, gvk := range gvks {
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			errs = append(errs, fmt.Errorf("%s is not available: %w", gvk, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,14 @@
+package health
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Health Suite")
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// syncCache is a cache whose sync state is controlled by the test.
+type syncCache struct {
+	cache.Cache
+	synced bool
+}
+
+func (c *syncCache) WaitForCacheSync(_ context.Context) bool {
+	return c.synced
+}
+
+// lockedMapper guards a RESTMapper the test adds kinds to while a check reads it.
+type lockedMapper struct {
+	sync.Mutex
+	*meta.DefaultRESTMapper
+}
+
+func (m *lockedMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.DefaultRESTMapper.RESTMapping(gk, versions...)
+}
+
+func (m *lockedMapper) Add(gvk schema.GroupVersionKind, scope meta.RESTScope) {
+	m.Lock()
+	defer m.Unlock()
+	m.DefaultRESTMapper.Add(gvk, scope)
+}
+
+var _ = Describe("Readiness checks", func() {
+	Context("CacheSynced", func() {
+		It("should fail before the cache syncs and pass after", func() {
+			c := &syncCache{}
+			check := CacheSynced(c)
+			req := httptest.NewRequest("GET", "/readyz", nil)
+
+			Expect(check(req)).To(MatchError(ContainSubstring("not synced")))
+
+			c.synced = true
+			Expect(check(req)).To(Succeed())
+		})
+	})
+
+	Context("CRDsInstalled", func() {
+		appGVK := schema.GroupVersionKind{Group: "workload.fcp.funccloud.com", Version: "v1alpha1", Kind: "Application"}
+		wsGVK := schema.GroupVersionKind{Group: "tenancy.fcp.funccloud.com", Version: "v1alpha1", Kind: "Workspace"}
+
+		It("should report the kinds that are not served", func() {
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(appGVK, meta.RESTScopeNamespace)
+			check := CRDsInstalled(mapper, appGVK, wsGVK)
+			req := httptest.NewRequest("GET", "/readyz", nil)
+
+			err := check(req)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Workspace"))
+			Expect(err.Error()).NotTo(ContainSubstring("Kind=Application"))
+
+			mapper.Add(wsGVK, meta.RESTScopeRoot)
+			Expect(check(req)).To(Succeed())
+		})
+	})
+
+	Context("WaitForCRDs", func() {
+		ksvcGVK := schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Service"}
+
+		It("should block until the missing kinds are served", func() {
+			mapper := &lockedMapper{DefaultRESTMapper: meta.NewDefaultRESTMapper(nil)}
+			check := CRDsInstalled(mapper, ksvcGVK)
+			req := httptest.NewRequest("GET", "/readyz", nil)
+			done := make(chan error)
+			go func() {
+				done <- WaitForCRDs(context.Background(), mapper, 10*time.Millisecond, ksvcGVK)
+			}()
+
+			Consistently(done, 100*time.Millisecond).ShouldNot(Receive())
+			Expect(check(req)).To(MatchError(ContainSubstring("serving.knative.dev/v1, Kind=Service")))
+
+			mapper.Add(ksvcGVK, meta.RESTScopeNamespace)
+			Eventually(done).Should(Receive(BeNil()))
+			Expect(check(req)).To(Succeed())
+		})
+
+		It("should give up when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			Expect(WaitForCRDs(ctx, meta.NewDefaultRESTMapper(nil), time.Millisecond, ksvcGVK)).To(HaveOccurred())
+		})
+	})
+})
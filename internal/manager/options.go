@@ -0,0 +1,83 @@
+// Package manager holds the controller-manager settings exposed as command line flags.
+package manager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultResyncPeriod matches the controller-runtime default for the cache sync period.
+	DefaultResyncPeriod = 10 * time.Hour
+	// MinResyncPeriod keeps periodic full reconciles from hammering the API server.
+	MinResyncPeriod = time.Minute
+	// MaxResyncPeriod makes sure out-of-band drift is repaired at least once a day.
+	MaxResyncPeriod = 24 * time.Hour
+
+	// knativeServingNamespace holds the Knative configuration the Application webhook reads.
+	knativeServingNamespace = "knative-serving"
+)
+
+// Config holds the tunable manager settings.
+type Config struct {
+	// ResyncPeriod is how often every watched object is reconciled again, even without events.
+	ResyncPeriod time.Duration
+	// WatchNamespaces restricts the cache of namespaced objects to these namespaces, so that
+	// sharded managers only hold their own Applications in memory. Empty watches every namespace.
+	// The workspace RBAC and ServiceAccounts are still cached in every namespace, and Applications,
+	// ConfigMaps and Secrets are read from the API server, since Workspaces and the webhooks are
+	// served for every namespace.
+	WatchNamespaces []string
+}
+
+// Validate checks that the settings are within their supported bounds.
+func (c Config) Validate() error {
+	if c.ResyncPeriod < MinResyncPeriod || c.ResyncPeriod > MaxResyncPeriod {
+		return fmt.Errorf("resync period %s must be between %s and %s", c.ResyncPeriod, MinResyncPeriod, MaxResyncPeriod)
+	}
+	for _, namespace := range c.WatchNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid watch namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// Apply validates the settings and sets them on the manager options.
+func (c Config) Apply(opts *ctrl.Options) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	resync := c.ResyncPeriod
+	opts.Cache.SyncPeriod = &resync
+	if len(c.WatchNamespaces) > 0 {
+		opts.Cache.DefaultNamespaces = make(map[string]cache.Config, len(c.WatchNamespaces)+1)
+		for _, namespace := range c.WatchNamespaces {
+			opts.Cache.DefaultNamespaces[namespace] = cache.Config{}
+		}
+		opts.Cache.DefaultNamespaces[knativeServingNamespace] = cache.Config{}
+		if opts.Cache.ByObject == nil {
+			opts.Cache.ByObject = make(map[client.Object]cache.ByObject)
+		}
+		for _, obj := range []client.Object{&rbacv1.Role{}, &rbacv1.RoleBinding{}, &corev1.ServiceAccount{}} {
+			opts.Cache.ByObject[obj] = cache.ByObject{
+				Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+			}
+		}
+		if opts.Client.Cache == nil {
+			opts.Client.Cache = &client.CacheOptions{}
+		}
+		opts.Client.Cache.DisableFor = append(opts.Client.Cache.DisableFor,
+			&workloadv1alpha1.Application{}, &corev1.ConfigMap{}, &corev1.Secret{})
+	}
+	return nil
+}
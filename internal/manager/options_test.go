@@ -0,0 +1,111 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	"go.funccloud.dev/fcp/internal/scheme"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+var _ = Describe("Manager options", func() {
+	Context("resync period", func() {
+		It("should pass the resync period to the cache options", func() {
+			opts := ctrl.Options{}
+			Expect(Config{ResyncPeriod: 30 * time.Minute}.Apply(&opts)).To(Succeed())
+			Expect(opts.Cache.SyncPeriod).NotTo(BeNil())
+			Expect(*opts.Cache.SyncPeriod).To(Equal(30 * time.Minute))
+		})
+
+		It("should reject periods outside the supported bounds", func() {
+			opts := ctrl.Options{}
+			Expect(Config{ResyncPeriod: time.Second}.Apply(&opts)).To(MatchError(ContainSubstring("must be between")))
+			Expect(Config{ResyncPeriod: 48 * time.Hour}.Apply(&opts)).To(MatchError(ContainSubstring("must be between")))
+			Expect(opts.Cache.SyncPeriod).To(BeNil())
+		})
+	})
+
+	Context("watch namespaces", func() {
+		It("should scope the cache to the given namespaces", func() {
+			opts := ctrl.Options{}
+			config := Config{ResyncPeriod: DefaultResyncPeriod, WatchNamespaces: []string{"team-a", "team-b"}}
+			Expect(config.Apply(&opts)).To(Succeed())
+			Expect(opts.Cache.DefaultNamespaces).To(HaveLen(3))
+			Expect(opts.Cache.DefaultNamespaces).To(HaveKey("team-a"))
+			Expect(opts.Cache.DefaultNamespaces).To(HaveKey("team-b"))
+			Expect(opts.Cache.DefaultNamespaces).To(HaveKey(knativeServingNamespace))
+		})
+
+		It("should watch every namespace by default", func() {
+			opts := ctrl.Options{}
+			Expect(Config{ResyncPeriod: DefaultResyncPeriod}.Apply(&opts)).To(Succeed())
+			Expect(opts.Cache.DefaultNamespaces).To(BeNil())
+		})
+
+		It("should keep reads outside the watched namespaces working", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			direct, err := client.New(cfg, client.Options{Scheme: scheme.Get()})
+			Expect(err).NotTo(HaveOccurred())
+			for _, name := range []string{"shard-a", "other", knativeServingNamespace} {
+				Expect(direct.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})).To(Succeed())
+			}
+			objects := []client.Object{
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "workspace", Namespace: "other"}},
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "workspace", Namespace: "other"},
+					RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "workspace"},
+				},
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "builder", Namespace: "other"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "trust-bundle", Namespace: "other"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "other"}},
+				&coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: "autoscaler", Namespace: knativeServingNamespace}},
+				&coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: "unwatched", Namespace: "other"}},
+			}
+			for _, obj := range objects {
+				Expect(direct.Create(ctx, obj)).To(Succeed())
+			}
+
+			opts := ctrl.Options{Scheme: scheme.Get(), Metrics: metricsserver.Options{BindAddress: "0"}}
+			config := Config{ResyncPeriod: DefaultResyncPeriod, WatchNamespaces: []string{"shard-a"}}
+			Expect(config.Apply(&opts)).To(Succeed())
+			mgr, err := ctrl.NewManager(cfg, opts)
+			Expect(err).NotTo(HaveOccurred())
+			go func() {
+				defer GinkgoRecover()
+				Expect(mgr.Start(ctx)).To(Succeed())
+			}()
+			Expect(mgr.GetCache().WaitForCacheSync(ctx)).To(BeTrue())
+
+			c := mgr.GetClient()
+			By("reading the workspace RBAC and ServiceAccounts in every namespace")
+			for _, obj := range objects[:3] {
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))).To(Succeed())
+			}
+			By("reading Applications, ConfigMaps and Secrets of other workspaces from the API server")
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(objects[3]), &corev1.ConfigMap{})).To(Succeed())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(objects[4]), &corev1.Secret{})).To(Succeed())
+			Expect(c.List(ctx, &workloadv1alpha1.ApplicationList{}, client.InNamespace("other"))).To(Succeed())
+			By("caching the knative-serving namespace")
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(objects[5]), &coordinationv1.Lease{})).To(Succeed())
+			By("still keeping other objects of unwatched namespaces out of the cache")
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(objects[6]), &coordinationv1.Lease{})).NotTo(Succeed())
+		})
+
+		It("should reject invalid namespace names", func() {
+			opts := ctrl.Options{}
+			config := Config{ResyncPeriod: DefaultResyncPeriod, WatchNamespaces: []string{"Team_A"}}
+			Expect(config.Apply(&opts)).To(MatchError(ContainSubstring("invalid watch namespace")))
+			Expect(opts.Cache.DefaultNamespaces).To(BeNil())
+		})
+	})
+})
@@ -18,6 +18,15 @@ const (
 	CertManagerDeployment = "cert-manager"
 )
 
+// Deployments returns the cert-manager deployments that must be available for it to issue certificates.
+func Deployments() []types.NamespacedName {
+	return []types.NamespacedName{
+		{Namespace: CertManagerNamespace, Name: CertManagerDeployment},
+		{Namespace: CertManagerNamespace, Name: "cert-manager-webhook"},
+		{Namespace: CertManagerNamespace, Name: "cert-manager-cainjector"},
+	}
+}
+
 // CheckOrInstallVersion checks if cert-manager is installed.
 // If not installed, it attempts to install the version defined in installer.go.
 // If installed, it checks if the version matches the expected one and logs a warning if different.
@@ -6,13 +6,12 @@ import (
 	"strings"
 	"time"
 
+	"go.funccloud.dev/fcp/internal/resource/readiness"
 	"go.funccloud.dev/fcp/internal/yamlutil"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
@@ -20,6 +19,9 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// crdEstablishDelay is the pause given to the API server to establish the cert-manager CRDs.
+const crdEstablishDelay = 10 * time.Second
+
 const (
 	CertManagerVersion             = "v1.17.1"
 	CertManagerManifestURLTemplate = "https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml"
@@ -42,7 +44,11 @@ func InstallCertManager(ctx context.Context, k8sClient client.Client, ioStreams
 
 	// Brief pause to allow CRDs to be established in the API server
 	_, _ = fmt.Fprintln(ioStreams.Out, "Waiting briefly for CRDs to be established...")
-	time.Sleep(10 * time.Second)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("interrupted while waiting for cert-manager CRDs: %w", ctx.Err())
+	case <-time.After(crdEstablishDelay):
+	}
 
 	// 2. Install main cert-manager components
 	manifestURL := fmt.Sprintf(CertManagerManifestURLTemplate, CertManagerVersion)
@@ -89,12 +95,11 @@ func InstallCertManager(ctx context.Context, k8sClient client.Client, ioStreams
 
 // waitForCertManagerDeployments waits for the main cert-manager deployments to be available.
 func waitForCertManagerDeployments(ctx context.Context, k8sClient client.Client, ioStreams genericiooptions.IOStreams) error {
-	deployments := []string{CertManagerDeployment, "cert-manager-webhook", "cert-manager-cainjector"}
-
-	for _, depName := range deployments {
+	for _, nn := range Deployments() {
+		depName := nn.Name
 		_, _ = fmt.Fprintln(ioStreams.Out, "Waiting for deployment", "deployment", depName, "namespace", CertManagerNamespace)
 		err := wait.PollUntilContextCancel(ctx, 5*time.Second, true, func(ctx context.Context) (bool, error) {
-			ready, err := isDeploymentReady(ctx, k8sClient, CertManagerNamespace, depName)
+			ready, err := readiness.DeploymentReady(ctx, k8sClient, nn)
 			if err != nil {
 				// If not found yet, keep waiting
 				if apierrors.IsNotFound(err) {
@@ -117,34 +122,6 @@ func waitForCertManagerDeployments(ctx context.Context, k8sClient client.Client,
 	return nil
 }
 
-// isDeploymentReady checks if a specific deployment is ready (available).
-func isDeploymentReady(ctx context.Context, k8sClient client.Client, namespace, name string) (bool, error) {
-	deployment := &appsv1.Deployment{}
-	namespacedName := types.NamespacedName{Namespace: namespace, Name: name}
-
-	err := k8sClient.Get(ctx, namespacedName, deployment)
-	if err != nil {
-		return false, err // Return the error (including NotFound)
-	}
-
-	// Check if the number of ready replicas equals the desired number
-	// and if the observed generation is the latest.
-	if deployment.Spec.Replicas != nil &&
-		deployment.Status.ObservedGeneration >= deployment.Generation &&
-		deployment.Status.UpdatedReplicas == *deployment.Spec.Replicas &&
-		deployment.Status.Replicas == *deployment.Spec.Replicas &&
-		deployment.Status.AvailableReplicas == *deployment.Spec.Replicas {
-		// Check deployment conditions
-		for _, cond := range deployment.Status.Conditions {
-			if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionTrue {
-				return true, nil
-			}
-		}
-	}
-
-	return false, nil // Not ready yet
-}
-
 // addTolerationsToManifest processes a YAML manifest string,
 // finds all Kubernetes Deployments and adds specified tolerations
 // to their pod templates if they don't already exist.
@@ -0,0 +1,33 @@
+package certmanager
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("cert-manager installer", func() {
+	It("should stop waiting for the deployments as soon as the parent context is cancelled", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		// No deployments exist, so the wait would otherwise poll until its timeout.
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		ioStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+		parent, cancel := context.WithCancel(context.Background())
+		waitCtx, waitCancel := context.WithTimeout(parent, 5*time.Minute)
+		defer waitCancel()
+		time.AfterFunc(200*time.Millisecond, cancel)
+
+		start := time.Now()
+		err := waitForCertManagerDeployments(waitCtx, k8sClient, ioStreams)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+	})
+})
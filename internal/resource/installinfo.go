@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.funccloud.dev/fcp/internal/resource/certmanager"
+	"go.funccloud.dev/fcp/internal/resource/knative"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// InstallInfoNamespace is the namespace holding the install-info ConfigMap.
+	InstallInfoNamespace = "fcp-system"
+	// InstallInfoConfigMapName is the ConfigMap recording the component versions installed by fcp.
+	InstallInfoConfigMapName = "fcp-install-info"
+
+	// ComponentCertManager is the install-info key for the cert-manager version.
+	ComponentCertManager = "cert-manager"
+	// ComponentKnative is the install-info key for the Knative Operator version.
+	ComponentKnative = "knative"
+)
+
+var (
+	// ErrDowngrade is returned when the target version of a component is older than the installed one.
+	ErrDowngrade = errors.New("refusing to downgrade")
+	// ErrAlreadyInstalled is returned when installing over a platform that already recorded its versions.
+	ErrAlreadyInstalled = errors.New("the platform is already installed")
+)
+
+// TargetVersions returns the component versions bundled with this fcp release.
+func TargetVersions() map[string]string {
+	return map[string]string{
+		ComponentCertManager: certmanager.CertManagerVersion,
+		ComponentKnative:     knative.KnativeOperatorVersion,
+	}
+}
+
+// GetInstalledVersions reads the component versions recorded by a previous install.
+// It returns a nil map when nothing has been recorded yet.
+func GetInstalledVersions(ctx context.Context, k8sClient client.Client) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: InstallInfoNamespace, Name: InstallInfoConfigMapName}
+	if err := k8sClient.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read install info %s: %w", key, err)
+	}
+	return cm.Data, nil
+}
+
+// RecordInstalledVersions stores the given component versions in the install-info ConfigMap,
+// keeping entries for components that are not part of versions.
+func RecordInstalledVersions(ctx context.Context, k8sClient client.Client, versions map[string]string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: InstallInfoNamespace}}
+	if err := k8sClient.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to ensure namespace %s: %w", InstallInfoNamespace, err)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstallInfoConfigMapName,
+			Namespace: InstallInfoNamespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		for component, v := range versions {
+			cm.Data[component] = v
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record install info: %w", err)
+	}
+	return nil
+}
+
+// PlanUpgrade compares the installed component versions with the target ones and returns the
+// components that need to be applied, sorted by name. Components missing from installed are always
+// included. A target older than the installed version returns ErrDowngrade unless force is set.
+func PlanUpgrade(installed, target map[string]string, force bool) ([]string, error) {
+	var changed []string
+	for component, targetVersion := range target {
+		installedVersion, ok := installed[component]
+		if !ok || installedVersion == "" {
+			changed = append(changed, component)
+			continue
+		}
+		if installedVersion == targetVersion {
+			continue
+		}
+		cmp, err := compareVersions(installedVersion, targetVersion)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compare %s versions: %w", component, err)
+		}
+		if cmp > 0 && !force {
+			return nil, fmt.Errorf("%w %s from %s to %s, use --force to override",
+				ErrDowngrade, component, installedVersion, targetVersion)
+		}
+		if cmp != 0 || force {
+			changed = append(changed, component)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// compareVersions returns -1, 0 or 1 when a is older, equal or newer than b.
+func compareVersions(a, b string) (int, error) {
+	va, err := version.ParseGeneric(a)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(b)
+}
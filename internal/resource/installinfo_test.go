@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/resource/knative"
+	"go.funccloud.dev/fcp/internal/scheme"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Install info", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		ioStreams genericiooptions.IOStreams
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme.Get()).Build()
+		ioStreams, _, _, _ = genericiooptions.NewTestIOStreams()
+	})
+
+	Context("PlanUpgrade", func() {
+		It("should include components whose target version is newer", func() {
+			installed := map[string]string{ComponentCertManager: "v1.16.0", ComponentKnative: "v1.18.1"}
+			target := map[string]string{ComponentCertManager: "v1.17.1", ComponentKnative: "v1.18.1"}
+			changed, err := PlanUpgrade(installed, target, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal([]string{ComponentCertManager}))
+		})
+
+		It("should include components that were never recorded", func() {
+			changed, err := PlanUpgrade(nil, TargetVersions(), false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal([]string{ComponentCertManager, ComponentKnative}))
+		})
+
+		It("should return nothing when versions match", func() {
+			changed, err := PlanUpgrade(TargetVersions(), TargetVersions(), false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeEmpty())
+		})
+
+		It("should refuse a downgrade without force", func() {
+			installed := map[string]string{ComponentKnative: "v1.19.0"}
+			target := map[string]string{ComponentKnative: "v1.18.1"}
+			_, err := PlanUpgrade(installed, target, false)
+			Expect(err).To(MatchError(ErrDowngrade))
+		})
+
+		It("should allow a downgrade with force", func() {
+			installed := map[string]string{ComponentKnative: "v1.19.0"}
+			target := map[string]string{ComponentKnative: "v1.18.1"}
+			changed, err := PlanUpgrade(installed, target, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal([]string{ComponentKnative}))
+		})
+	})
+
+	Context("Recording versions", func() {
+		It("should return nil when nothing was recorded", func() {
+			installed, err := GetInstalledVersions(ctx, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installed).To(BeNil())
+		})
+
+		It("should merge recorded versions", func() {
+			Expect(RecordInstalledVersions(ctx, k8sClient, TargetVersions())).To(Succeed())
+			Expect(RecordInstalledVersions(ctx, k8sClient, map[string]string{ComponentKnative: "v9.9.9"})).To(Succeed())
+			installed, err := GetInstalledVersions(ctx, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installed).To(HaveKeyWithValue(ComponentCertManager, TargetVersions()[ComponentCertManager]))
+			Expect(installed).To(HaveKeyWithValue(ComponentKnative, "v9.9.9"))
+		})
+	})
+
+	Context("CheckOrInstallVersion", func() {
+		It("should refuse to install over a platform with recorded versions", func() {
+			Expect(RecordInstalledVersions(ctx, k8sClient, TargetVersions())).To(Succeed())
+			err := CheckOrInstallVersion(ctx, "example.com", GinkgoT().TempDir(), nil, knative.ServingConfig{}, types.NamespacedName{}, k8sClient, ioStreams)
+			Expect(err).To(MatchError(ErrAlreadyInstalled))
+			Expect(err.Error()).To(ContainSubstring("--upgrade"))
+		})
+	})
+
+	Context("Upgrade", func() {
+		It("should be a no-op when the platform is up to date", func() {
+			Expect(RecordInstalledVersions(ctx, k8sClient, TargetVersions())).To(Succeed())
+			Expect(Upgrade(ctx, "example.com", GinkgoT().TempDir(), false, nil, knative.ServingConfig{}, types.NamespacedName{}, k8sClient, ioStreams)).To(Succeed())
+		})
+
+		It("should block a downgrade", func() {
+			Expect(RecordInstalledVersions(ctx, k8sClient, map[string]string{
+				ComponentCertManager: "v99.0.0",
+				ComponentKnative:     TargetVersions()[ComponentKnative],
+			})).To(Succeed())
+			err := Upgrade(ctx, "example.com", GinkgoT().TempDir(), false, nil, knative.ServingConfig{}, types.NamespacedName{}, k8sClient, ioStreams)
+			Expect(err).To(MatchError(ErrDowngrade))
+			installed, err := GetInstalledVersions(ctx, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installed).To(HaveKeyWithValue(ComponentCertManager, "v99.0.0"))
+		})
+	})
+})
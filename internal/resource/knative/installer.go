@@ -1,26 +1,27 @@
 package knative
 
 import (
-	"bytes"
 	"context"
 	_ "embed" // Import the embed package
 	"fmt"
 	"io"
 	"strings"
-	"text/template"
 	"time"
 
 	"go.funccloud.dev/fcp/internal/yamlutil"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	knativeoperatorv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
@@ -31,14 +32,17 @@ var knativeServingYAML []byte // Embed the knative.yaml file
 //go:embed default-issuer.yaml
 var defaultIssuerYAML string // Embed the default issuer YAML
 
+// selfSignedIssuerName is the CA ClusterIssuer of default-issuer.yaml, used for non-public domains.
+const selfSignedIssuerName = "knative-selfsigned-issuer"
+
 const (
 	// Knative Operator version and URL
-	knativeOperatorVersion = "v1.18.1"
+	KnativeOperatorVersion = "v1.18.1"
 	knativeVersion         = "v1.18.0"
 	netContourVersion      = "v1.18.0"
 
 	knativeOperatorURL = "https://github.com/knative/operator/releases/download/knative-" +
-		knativeOperatorVersion + "/operator.yaml"
+		KnativeOperatorVersion + "/operator.yaml"
 
 	// Knative Serving Default Domain URL (for Kind)
 	knativeServingDefaultDomainURL = "https://github.com/knative/serving/releases/download/knative-" +
@@ -75,6 +79,7 @@ func InstallKnative(
 	ctx context.Context,
 	domain, issuerName string,
 	isKind bool,
+	servingConfig ServingConfig,
 	k8sClient client.Client,
 	ioStreams genericiooptions.IOStreams,
 ) error {
@@ -158,28 +163,10 @@ func InstallKnative(
 	// 5. Apply KnativeServing CR from embedded YAML
 	_, _ = fmt.Fprintln(ioStreams.Out, "Applying KnativeServing custom resource from embedded YAML...",
 		"namespace", knativeServingNamespace, "name", knativeServingCRName)
-	tpl, err := template.New("knativeServingTemplate").Parse(string(knativeServingYAML))
-	if err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to parse embedded KnativeServing YAML template", "error", err)
-		return fmt.Errorf("failed to parse embedded KnativeServing YAML template: %w", err)
-	}
-	buff := bytes.Buffer{}
-	err = tpl.Execute(&buff, map[string]any{
-		"Domain":     domain,
-		"IssuerName": issuerName,
-		"IsKind":     isKind,
-	})
+	knativeServingCR, err := renderKnativeServing(domain, issuerName, isKind, servingConfig)
 	if err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to execute embedded KnativeServing YAML template", "error", err)
-		return fmt.Errorf("failed to execute embedded KnativeServing YAML template: %w", err)
-	}
-	// Decode the embedded YAML into an unstructured object
-	knativeServingCR := &unstructured.Unstructured{}
-	// Use k8syaml for decoding from a reader
-	decoder := k8syaml.NewYAMLOrJSONDecoder(&buff, 4096)
-	if err := decoder.Decode(knativeServingCR); err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to decode embedded KnativeServing YAML", "error", err)
-		return fmt.Errorf("failed to decode embedded KnativeServing YAML: %w", err)
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to render embedded KnativeServing YAML", "error", err)
+		return err
 	}
 
 	// Ensure the namespace is set correctly (it should be in the YAML, but double-check)
@@ -189,12 +176,7 @@ func InstallKnative(
 		knativeServingCR.SetNamespace(knativeServingNamespace)
 	}
 
-	// Use Server-Side Apply (SSA)
-	patch := client.Apply
-	force := true
-	patchOptions := &client.PatchOptions{FieldManager: "fcp-controller", Force: &force}
-	// Assign error to existing 'err' variable
-	if err = k8sClient.Patch(ctx, knativeServingCR, patch, patchOptions); err != nil {
+	if err = applyKnativeServing(ctx, k8sClient, knativeServingCR, ioStreams); err != nil {
 		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to apply KnativeServing custom resource",
 			"namespace", knativeServingNamespace, "name", knativeServingCRName, "error", err)
 		return fmt.Errorf("failed to apply KnativeServing CR %s/%s: %w",
@@ -225,25 +207,88 @@ func InstallKnative(
 	return nil
 }
 
-// waitForOperatorManagedDeploymentsReady waits for the core Knative Serving deployments created by the Operator.
-func waitForOperatorManagedDeploymentsReady(
+// applyKnativeServing creates the KnativeServing CR with server-side apply or, when it already exists,
+// replaces its spec with the desired one so that fields dropped from knative.yaml by a newer fcp
+// release do not linger on the cluster.
+func applyKnativeServing(
 	ctx context.Context,
 	k8sClient client.Client,
+	desired *unstructured.Unstructured,
 	ioStreams genericiooptions.IOStreams,
 ) error {
-	waitCtx, waitCancel := context.WithTimeout(ctx, waitTimeout)
-	defer waitCancel()
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		force := true
+		return k8sClient.Patch(ctx, desired, client.Apply,
+			&client.PatchOptions{FieldManager: "fcp-controller", Force: &force})
+	}
+	if err != nil {
+		return err
+	}
+
+	if running, found, _ := unstructured.NestedString(existing.Object, "status", "version"); found &&
+		strings.TrimPrefix(running, "v") != strings.TrimPrefix(knativeVersion, "v") {
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Warning: running Knative Serving version differs from the target version",
+			"running", running, "target", knativeVersion)
+	}
 
+	upToDate, err := sameKnativeServingSpec(existing, desired)
+	if err != nil {
+		return err
+	}
+	if upToDate {
+		_, _ = fmt.Fprintln(ioStreams.Out, "KnativeServing custom resource is up to date.")
+		return nil
+	}
+	_, _ = fmt.Fprintln(ioStreams.Out, "Updating KnativeServing custom resource spec to the bundled configuration.")
+	existing.Object["spec"] = desired.Object["spec"]
+	return k8sClient.Update(ctx, existing)
+}
+
+// sameKnativeServingSpec compares the specs through the typed KnativeServing so that fields set to
+// their zero value on the cluster do not count as a difference.
+func sameKnativeServingSpec(a, b *unstructured.Unstructured) (bool, error) {
+	var typedA, typedB knativeoperatorv1beta1.KnativeServing
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(a.Object, &typedA); err != nil {
+		return false, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(b.Object, &typedB); err != nil {
+		return false, err
+	}
+	return equality.Semantic.DeepEqual(typedA.Spec, typedB.Spec), nil
+}
+
+// Deployments returns the Knative Operator deployment followed by the Serving deployments it manages.
+func Deployments() []types.NamespacedName {
+	return append([]types.NamespacedName{
+		{Namespace: knativeOperatorNamespace, Name: knativeOperatorDeployment},
+	}, servingDeployments()...)
+}
+
+// servingDeployments returns the core Knative Serving deployments created by the Operator.
+func servingDeployments() []types.NamespacedName {
 	// Components expected to be created by the Operator in knative-serving namespace
 	// Kourier deployment name might vary, add if needed after checking operator behavior
-	components := []types.NamespacedName{
+	return []types.NamespacedName{
 		{Namespace: knativeServingNamespace, Name: knativeServingController},
 		{Namespace: knativeServingNamespace, Name: knativeServingWebhook},
 		// Add Kourier deployment if its name is known and consistent, e.g.:
 		// {Namespace: knativeServingNamespace, Name: "net-kourier-controller"},
 	}
+}
+
+// waitForOperatorManagedDeploymentsReady waits for the core Knative Serving deployments created by the Operator.
+func waitForOperatorManagedDeploymentsReady(
+	ctx context.Context,
+	k8sClient client.Client,
+	ioStreams genericiooptions.IOStreams,
+) error {
+	waitCtx, waitCancel := context.WithTimeout(ctx, waitTimeout)
+	defer waitCancel()
 
-	for _, nn := range components {
+	for _, nn := range servingDeployments() {
 		_, _ = fmt.Fprintln(ioStreams.Out, "Waiting for Operator-managed deployment...",
 			"namespace", nn.Namespace, "name", nn.Name)
 		if err := waitForDeploymentReady(waitCtx, k8sClient, nn); err != nil {
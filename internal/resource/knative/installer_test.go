@@ -0,0 +1,125 @@
+package knative
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/scheme"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("KnativeServing apply", func() {
+	var (
+		ctx       context.Context
+		ioStreams genericiooptions.IOStreams
+		errOut    *bytes.Buffer
+	)
+
+	knativeServingGVK := schema.GroupVersionKind{Group: "operator.knative.dev", Version: "v1beta1", Kind: "KnativeServing"}
+
+	newKnativeServing := func(spec map[string]any) *unstructured.Unstructured {
+		ks := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		ks.SetGroupVersionKind(knativeServingGVK)
+		ks.SetNamespace(knativeServingNamespace)
+		ks.SetName(knativeServingCRName)
+		return ks
+	}
+
+	desiredSpec := func() map[string]any {
+		return map[string]any{
+			"config": map[string]any{
+				"features": map[string]any{"multi-container": "enabled"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ioStreams, _, _, errOut = genericiooptions.NewTestIOStreams()
+	})
+
+	It("should replace the spec of a CR applied by a previous release", func() {
+		existing := newKnativeServing(map[string]any{
+			"config": map[string]any{
+				"features": map[string]any{"multi-container": "enabled", "removed-flag": "enabled"},
+			},
+		})
+		Expect(unstructured.SetNestedField(existing.Object, "1.17.0", "status", "version")).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(existing).Build()
+
+		Expect(applyKnativeServing(ctx, k8sClient, newKnativeServing(desiredSpec()), ioStreams)).To(Succeed())
+
+		updated := &unstructured.Unstructured{}
+		updated.SetGroupVersionKind(knativeServingGVK)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existing), updated)).To(Succeed())
+		features, _, _ := unstructured.NestedStringMap(updated.Object, "spec", "config", "features")
+		Expect(features).To(Equal(map[string]string{"multi-container": "enabled"}))
+		Expect(errOut.String()).To(ContainSubstring("running Knative Serving version differs"))
+	})
+
+	It("should leave an up to date CR untouched", func() {
+		existing := newKnativeServing(desiredSpec())
+		Expect(unstructured.SetNestedField(existing.Object, knativeVersion, "status", "version")).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(existing).Build()
+		before := &unstructured.Unstructured{}
+		before.SetGroupVersionKind(knativeServingGVK)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existing), before)).To(Succeed())
+
+		Expect(applyKnativeServing(ctx, k8sClient, newKnativeServing(desiredSpec()), ioStreams)).To(Succeed())
+
+		after := &unstructured.Unstructured{}
+		after.SetGroupVersionKind(knativeServingGVK)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existing), after)).To(Succeed())
+		Expect(after.GetResourceVersion()).To(Equal(before.GetResourceVersion()))
+		Expect(errOut.String()).To(BeEmpty())
+	})
+
+	Context("on a Ready Knative Serving", func() {
+		var k8sClient client.Client
+
+		BeforeEach(func() {
+			existing := newKnativeServing(desiredSpec())
+			Expect(unstructured.SetNestedSlice(existing.Object, []any{
+				map[string]any{"type": "Ready", "status": "True"},
+			}, "status", "conditions")).To(Succeed())
+			k8sClient = fake.NewClientBuilder().WithScheme(scheme.Get()).WithObjects(existing).
+				WithInterceptorFuncs(interceptor.Funcs{
+					// Emulates server-side apply of the issuer, which the fake client does not implement.
+					Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch,
+						opts ...client.PatchOption) error {
+						return cl.Create(ctx, obj)
+					},
+				}).Build()
+		})
+
+		current := func() *unstructured.Unstructured {
+			ks := &unstructured.Unstructured{}
+			ks.SetGroupVersionKind(knativeServingGVK)
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: knativeServingNamespace, Name: knativeServingCRName}, ks)).To(Succeed())
+			return ks
+		}
+
+		It("should apply a requested serving config", func() {
+			servingConfig := ServingConfig{RevisionTimeout: 15 * time.Minute}
+			_, err := CheckOrInstallVersion(ctx, "example.com", servingConfig, k8sClient, ioStreams, false)
+			Expect(err).NotTo(HaveOccurred())
+			defaults, _, _ := unstructured.NestedStringMap(current().Object, "spec", "config", "defaults")
+			Expect(defaults).To(HaveKeyWithValue("revision-timeout-seconds", "900"))
+		})
+
+		It("should leave the CR untouched without a serving config", func() {
+			before := current()
+			_, err := CheckOrInstallVersion(ctx, "example.com", ServingConfig{}, k8sClient, ioStreams, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(current().GetResourceVersion()).To(Equal(before.GetResourceVersion()))
+		})
+	})
+})
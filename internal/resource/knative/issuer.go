@@ -0,0 +1,51 @@
+package knative
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var clusterIssuerGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "ClusterIssuer",
+}
+
+// checkIssuer verifies that the cert-manager ClusterIssuer used by Knative exists and is able to
+// issue certificates for the install domain, so a mismatch fails the install instead of leaving
+// every Application without a certificate.
+func checkIssuer(ctx context.Context, k8sClient client.Client, issuerName, domain string) error {
+	issuer := &unstructured.Unstructured{}
+	issuer.SetGroupVersionKind(clusterIssuerGVK)
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: issuerName}, issuer); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return fmt.Errorf("cluster issuer %q not found, make sure cert-manager is installed and the issuer exists: %w",
+				issuerName, err)
+		}
+		return fmt.Errorf("failed to get cluster issuer %q: %w", issuerName, err)
+	}
+
+	// ACME servers only issue certificates for publicly resolvable names.
+	if _, isACME, _ := unstructured.NestedMap(issuer.Object, "spec", "acme"); isACME && !isPublicDomain(domain) {
+		return fmt.Errorf("cluster issuer %q uses ACME, which cannot issue certificates for domain %q; "+
+			"use a public domain or a CA issuer instead", issuerName, domain)
+	}
+	return nil
+}
+
+// isPublicDomain reports whether domain can be validated by a public ACME server.
+func isPublicDomain(domain string) bool {
+	if net.ParseIP(domain) != nil || !strings.Contains(domain, ".") {
+		return false
+	}
+	return domain != "localhost" && !strings.HasSuffix(domain, ".local") && !strings.HasSuffix(domain, ".localhost")
+}
@@ -0,0 +1,94 @@
+package knative
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("issuer pre-flight check", func() {
+	var ctx context.Context
+
+	newIssuer := func(name string, spec map[string]any) client.Object {
+		issuer := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		issuer.SetGroupVersionKind(clusterIssuerGVK)
+		issuer.SetName(name)
+		return issuer
+	}
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(objs...).Build()
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should fail when the issuer does not exist", func() {
+		err := checkIssuer(ctx, newClient(), "le-prod-issuer", "example.com")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`cluster issuer "le-prod-issuer" not found`))
+	})
+
+	It("should fail when an ACME issuer cannot cover the domain", func() {
+		k8sClient := newClient(newIssuer("le-prod-issuer", map[string]any{"acme": map[string]any{}}))
+		for _, domain := range []string{"localhost", "fcp.local", "10.0.0.1", "intranet"} {
+			err := checkIssuer(ctx, k8sClient, "le-prod-issuer", domain)
+			Expect(err).To(HaveOccurred(), domain)
+			Expect(err.Error()).To(ContainSubstring("cannot issue certificates for domain"))
+		}
+	})
+
+	It("should accept an ACME issuer for a public domain", func() {
+		k8sClient := newClient(newIssuer("le-prod-issuer", map[string]any{"acme": map[string]any{}}))
+		Expect(checkIssuer(ctx, k8sClient, "le-prod-issuer", "example.com")).To(Succeed())
+		Expect(checkIssuer(ctx, k8sClient, "le-prod-issuer", "127.0.0.1.sslip.io")).To(Succeed())
+	})
+
+	It("should accept a CA issuer for any domain", func() {
+		k8sClient := newClient(newIssuer("ca-issuer", map[string]any{"ca": map[string]any{"secretName": "ca"}}))
+		Expect(checkIssuer(ctx, k8sClient, "ca-issuer", "fcp.local")).To(Succeed())
+	})
+
+	Context("applyIssuer", func() {
+		// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not implement.
+		applyAsCreateOrUpdate := interceptor.Funcs{
+			Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch,
+				opts ...client.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return cl.Patch(ctx, obj, patch, opts...)
+				}
+				err := cl.Create(ctx, obj)
+				if apierrors.IsAlreadyExists(err) {
+					return cl.Update(ctx, obj)
+				}
+				return err
+			},
+		}
+
+		DescribeTable("should pick an issuer that passes the pre-flight check",
+			func(domain string, isKind bool, want string) {
+				k8sClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).
+					WithInterceptorFuncs(applyAsCreateOrUpdate).Build()
+				ioStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+				issuerName, err := applyIssuer(ctx, domain, k8sClient, ioStreams, isKind)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(issuerName).To(Equal(want))
+			},
+			Entry("localhost", "localhost", false, selfSignedIssuerName),
+			Entry("localhost on Kind", "localhost", true, selfSignedIssuerName),
+			Entry("a .local domain", "fcp.local", false, selfSignedIssuerName),
+			Entry("a public domain on Kind", "127.0.0.1.sslip.io", true, "le-staging-issuer"),
+			Entry("a public domain", "example.com", false, "le-prod-issuer"),
+		)
+	})
+})
@@ -4,7 +4,6 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"strings"
 
 	"go.funccloud.dev/fcp/internal/scheme"
 	"go.funccloud.dev/fcp/internal/yamlutil"
@@ -25,9 +24,10 @@ var leProdIssuerYAML string
 var leStagingIssuerYAML string
 
 // CheckOrInstallVersion checks if Knative Serving (managed by Operator) is installed and ready.
-// If not installed or not ready, it attempts to install using the Knative Operator after applying the appropriate Let's Encrypt issuer.
+// If not installed or not ready, it attempts to install using the Knative Operator after applying the appropriate certificate issuer.
+// If it is Ready and a serving config is requested, the config is applied to the existing KnativeServing CR.
 // Returns an error if the check fails or if installation is required and fails.
-func CheckOrInstallVersion(ctx context.Context, domain string, k8sClient client.Client, ioStreams genericiooptions.IOStreams, isKind bool) (string, error) { // Added isKind parameter
+func CheckOrInstallVersion(ctx context.Context, domain string, servingConfig ServingConfig, k8sClient client.Client, ioStreams genericiooptions.IOStreams, isKind bool) (string, error) { // Added isKind parameter
 
 	// Check for the KnativeServing CR status first, as this indicates Operator success
 	// Ensure knativeServingNamespace and knativeServingCRName are accessible from installer.go (same package)
@@ -60,7 +60,10 @@ func CheckOrInstallVersion(ctx context.Context, domain string, k8sClient client.
 					if condStatus == string(metav1.ConditionTrue) {
 						_, _ = fmt.Fprintln(ioStreams.Out, "Knative Serving (managed by Operator) is installed and Ready.")
 						scheme.AddKnative() // Add Knative scheme to the runtime scheme
-						return "", nil      // Already installed and ready
+						if servingConfig.IsZero() {
+							return "", nil // Already installed and ready
+						}
+						return "", ApplyServingConfig(ctx, domain, servingConfig, k8sClient, ioStreams, isKind)
 					}
 					// Found Ready condition, but it's not True
 					_, _ = fmt.Fprintln(ioStreams.Out, "KnativeServing CR found but not Ready.", "status", condStatus)
@@ -88,27 +91,14 @@ func CheckOrInstallVersion(ctx context.Context, domain string, k8sClient client.
 	}
 	var issuerName string
 	if needsInstall {
-		// Apply the appropriate Let's Encrypt issuer before installing Knative
-		var issuerYAML string
-		if isKind || domain == "localhost" || strings.HasSuffix(domain, ".local") {
-			_, _ = fmt.Fprintln(ioStreams.Out, "Applying Let's Encrypt staging issuer for Kind cluster...")
-			issuerYAML = leStagingIssuerYAML
-			issuerName = "le-staging-issuer" // Assuming name from YAML
-		} else {
-			_, _ = fmt.Fprintln(ioStreams.Out, "Applying Let's Encrypt production issuer...")
-			issuerYAML = leProdIssuerYAML
-			issuerName = "le-prod-issuer" // Assuming name from YAML
-		}
-
-		applyErr := yamlutil.ApplyManifestYAML(ctx, k8sClient, issuerYAML, ioStreams)
-		if applyErr != nil {
-			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to apply Let's Encrypt issuer", "issuer", issuerName, "error", applyErr)
-			return "", fmt.Errorf("failed to apply Let's Encrypt issuer %s: %w", issuerName, applyErr)
+		// Apply the appropriate certificate issuer before installing Knative
+		issuerName, err = applyIssuer(ctx, domain, k8sClient, ioStreams, isKind)
+		if err != nil {
+			return "", err
 		}
-		_, _ = fmt.Fprintln(ioStreams.Out, "Successfully applied Let's Encrypt issuer", "issuer", issuerName)
 
 		_, _ = fmt.Fprintln(ioStreams.Out, "Attempting Knative Serving installation/reconciliation...")
-		installErr := InstallKnative(ctx, domain, issuerName, isKind, k8sClient, ioStreams)
+		installErr := InstallKnative(ctx, domain, issuerName, isKind, servingConfig, k8sClient, ioStreams)
 		if installErr != nil {
 			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to install/reconcile Knative Serving using Operator", "error", installErr)
 			return "", fmt.Errorf("failed to install/reconcile Knative Serving using Operator: %w", installErr)
@@ -119,3 +109,74 @@ func CheckOrInstallVersion(ctx context.Context, domain string, k8sClient client.
 	scheme.AddKnative()    // Add Knative scheme to the runtime scheme (safe to call multiple times)
 	return issuerName, nil // Successful installation or already existed and ready
 }
+
+// Upgrade re-applies the Knative Operator, networking layer and KnativeServing CR at the versions
+// bundled with this fcp release, regardless of whether Knative Serving is currently Ready.
+func Upgrade(ctx context.Context, domain string, servingConfig ServingConfig, k8sClient client.Client, ioStreams genericiooptions.IOStreams, isKind bool) error {
+	issuerName, err := applyIssuer(ctx, domain, k8sClient, ioStreams, isKind)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(ioStreams.Out, "Upgrading Knative Serving...", "operatorVersion", KnativeOperatorVersion)
+	if err := InstallKnative(ctx, domain, issuerName, isKind, servingConfig, k8sClient, ioStreams); err != nil {
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to upgrade Knative Serving using Operator", "error", err)
+		return fmt.Errorf("failed to upgrade Knative Serving using Operator: %w", err)
+	}
+	scheme.AddKnative()
+	return nil
+}
+
+// ApplyServingConfig renders the KnativeServing CR with servingConfig and applies it to an installed
+// Knative Serving, without reinstalling the operator or the networking layer. The rendered spec
+// replaces the current one, so settings of earlier runs that are not requested again are dropped.
+func ApplyServingConfig(ctx context.Context, domain string, servingConfig ServingConfig, k8sClient client.Client, ioStreams genericiooptions.IOStreams, isKind bool) error {
+	issuerName, err := applyIssuer(ctx, domain, k8sClient, ioStreams, isKind)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(ioStreams.Out, "Applying Knative Serving config to the KnativeServing custom resource...",
+		"namespace", knativeServingNamespace, "name", knativeServingCRName)
+	knativeServingCR, err := renderKnativeServing(domain, issuerName, isKind, servingConfig)
+	if err != nil {
+		return err
+	}
+	knativeServingCR.SetNamespace(knativeServingNamespace)
+	if err := applyKnativeServing(ctx, k8sClient, knativeServingCR, ioStreams); err != nil {
+		return fmt.Errorf("failed to apply KnativeServing CR %s/%s: %w",
+			knativeServingNamespace, knativeServingCRName, err)
+	}
+	return nil
+}
+
+// applyIssuer applies the issuer matching the target environment and returns its name. Let's Encrypt
+// only issues certificates for public domains, so other domains get the self-signed CA issuer.
+func applyIssuer(ctx context.Context, domain string, k8sClient client.Client, ioStreams genericiooptions.IOStreams, isKind bool) (string, error) {
+	var issuerYAML, issuerName string
+	switch {
+	case !isPublicDomain(domain):
+		_, _ = fmt.Fprintln(ioStreams.Out, "Applying self-signed CA issuer for non-public domain...", "domain", domain)
+		issuerYAML = defaultIssuerYAML
+		issuerName = selfSignedIssuerName
+	case isKind:
+		_, _ = fmt.Fprintln(ioStreams.Out, "Applying Let's Encrypt staging issuer for Kind cluster...")
+		issuerYAML = leStagingIssuerYAML
+		issuerName = "le-staging-issuer" // Assuming name from YAML
+	default:
+		_, _ = fmt.Fprintln(ioStreams.Out, "Applying Let's Encrypt production issuer...")
+		issuerYAML = leProdIssuerYAML
+		issuerName = "le-prod-issuer" // Assuming name from YAML
+	}
+
+	applyErr := yamlutil.ApplyManifestYAML(ctx, k8sClient, issuerYAML, ioStreams)
+	if applyErr != nil {
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to apply issuer", "issuer", issuerName, "error", applyErr)
+		return "", fmt.Errorf("failed to apply issuer %s: %w", issuerName, applyErr)
+	}
+	_, _ = fmt.Fprintln(ioStreams.Out, "Successfully applied issuer", "issuer", issuerName)
+
+	if err := checkIssuer(ctx, k8sClient, issuerName, domain); err != nil {
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Issuer pre-flight check failed", "issuer", issuerName, "error", err)
+		return "", err
+	}
+	return issuerName, nil
+}
@@ -0,0 +1,14 @@
+package knative
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKnative(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Knative Suite")
+}
@@ -0,0 +1,101 @@
+package knative
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// FeatureMultiContainer allows more than one container per revision.
+	FeatureMultiContainer = "multi-container"
+	// FeatureInitContainers allows init containers in the revision pod spec.
+	FeatureInitContainers = "kubernetes.podspec-init-containers"
+	// FeatureAffinity allows node and pod affinity in the revision pod spec.
+	FeatureAffinity = "kubernetes.podspec-affinity"
+
+	// defaultMaxRevisionTimeout mirrors Knative's max-revision-timeout-seconds default; a longer
+	// revision timeout must raise the maximum along with it.
+	defaultMaxRevisionTimeout = 600 * time.Second
+)
+
+// defaultFeatures are the Knative feature flags fcp enables unless told otherwise.
+var defaultFeatures = map[string]string{
+	FeatureMultiContainer:                          "enabled",
+	"kubernetes.podspec-fieldref":                  "enabled",
+	"kubernetes.podspec-topologyspreadconstraints": "enabled",
+}
+
+// ServingConfig holds the user tunable parts of the KnativeServing CR config.
+type ServingConfig struct {
+	// RegistriesSkippingTagResolving lists registries whose image tags are not resolved to digests.
+	RegistriesSkippingTagResolving []string
+	// RevisionTimeout is the default request timeout of a revision, zero keeps Knative's default.
+	RevisionTimeout time.Duration
+	// Features overrides Knative feature flags by name, e.g. kubernetes.podspec-affinity=enabled.
+	Features map[string]string
+}
+
+// IsZero reports whether no setting was requested, leaving the KnativeServing CR as it is.
+func (c ServingConfig) IsZero() bool {
+	return len(c.RegistriesSkippingTagResolving) == 0 && c.RevisionTimeout == 0 && len(c.Features) == 0
+}
+
+// Validate checks the feature flag values and the revision timeout.
+func (c ServingConfig) Validate() error {
+	for name, value := range c.Features {
+		switch value {
+		case "enabled", "disabled", "allowed":
+		default:
+			return fmt.Errorf("invalid value %q for Knative feature %s, must be enabled, disabled or allowed", value, name)
+		}
+	}
+	if c.RevisionTimeout < 0 || c.RevisionTimeout%time.Second != 0 {
+		return fmt.Errorf("revision timeout %s must be a positive whole number of seconds", c.RevisionTimeout)
+	}
+	return nil
+}
+
+// templateData returns the values the embedded knative.yaml template is rendered with.
+func (c ServingConfig) templateData(domain, issuerName string, isKind bool) map[string]any {
+	features := maps.Clone(defaultFeatures)
+	maps.Copy(features, c.Features)
+	data := map[string]any{
+		"Domain":                         domain,
+		"IssuerName":                     issuerName,
+		"IsKind":                         isKind,
+		"Features":                       features,
+		"RegistriesSkippingTagResolving": strings.Join(c.RegistriesSkippingTagResolving, ","),
+	}
+	if c.RevisionTimeout > 0 {
+		data["RevisionTimeoutSeconds"] = int64(c.RevisionTimeout / time.Second)
+		if c.RevisionTimeout > defaultMaxRevisionTimeout {
+			data["MaxRevisionTimeoutSeconds"] = int64(c.RevisionTimeout / time.Second)
+		}
+	}
+	return data
+}
+
+// renderKnativeServing renders the embedded knative.yaml template into a KnativeServing object.
+func renderKnativeServing(domain, issuerName string, isKind bool, servingConfig ServingConfig) (*unstructured.Unstructured, error) {
+	tpl, err := template.New("knativeServingTemplate").Parse(string(knativeServingYAML))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedded KnativeServing YAML template: %w", err)
+	}
+	buff := bytes.Buffer{}
+	if err := tpl.Execute(&buff, servingConfig.templateData(domain, issuerName, isKind)); err != nil {
+		return nil, fmt.Errorf("failed to execute embedded KnativeServing YAML template: %w", err)
+	}
+	knativeServingCR := &unstructured.Unstructured{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(&buff, 4096)
+	if err := decoder.Decode(knativeServingCR); err != nil {
+		return nil, fmt.Errorf("failed to decode embedded KnativeServing YAML: %w", err)
+	}
+	return knativeServingCR, nil
+}
@@ -0,0 +1,80 @@
+package knative
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("KnativeServing template", func() {
+	render := func(servingConfig ServingConfig) *unstructured.Unstructured {
+		ks, err := renderKnativeServing("apps.example.com", "letsencrypt-prod", false, servingConfig)
+		Expect(err).NotTo(HaveOccurred())
+		return ks
+	}
+
+	configMap := func(ks *unstructured.Unstructured, name string) map[string]string {
+		values, _, err := unstructured.NestedStringMap(ks.Object, "spec", "config", name)
+		Expect(err).NotTo(HaveOccurred())
+		return values
+	}
+
+	It("should render the defaults without optional config", func() {
+		ks := render(ServingConfig{})
+
+		Expect(ks.GetName()).To(Equal(knativeServingCRName))
+		Expect(configMap(ks, "domain")).To(HaveKey("apps.example.com"))
+		Expect(configMap(ks, "features")).To(Equal(defaultFeatures))
+		Expect(configMap(ks, "deployment")).To(BeEmpty())
+		Expect(configMap(ks, "defaults")).To(BeEmpty())
+	})
+
+	It("should render the registries skipping tag resolving", func() {
+		ks := render(ServingConfig{RegistriesSkippingTagResolving: []string{"kind.local", "dev.local"}})
+
+		Expect(configMap(ks, "deployment")).To(Equal(map[string]string{
+			"registries-skipping-tag-resolving": "kind.local,dev.local",
+		}))
+	})
+
+	It("should render the default revision timeout", func() {
+		ks := render(ServingConfig{RevisionTimeout: 2 * time.Minute})
+
+		Expect(configMap(ks, "defaults")).To(Equal(map[string]string{"revision-timeout-seconds": "120"}))
+	})
+
+	It("should raise the maximum revision timeout along with a longer default", func() {
+		ks := render(ServingConfig{RevisionTimeout: 15 * time.Minute})
+
+		Expect(configMap(ks, "defaults")).To(Equal(map[string]string{
+			"revision-timeout-seconds":     "900",
+			"max-revision-timeout-seconds": "900",
+		}))
+	})
+
+	It("should merge feature flags over the defaults", func() {
+		ks := render(ServingConfig{Features: map[string]string{
+			FeatureMultiContainer: "disabled",
+			FeatureInitContainers: "enabled",
+			FeatureAffinity:       "enabled",
+		}})
+
+		features := configMap(ks, "features")
+		Expect(features).To(HaveKeyWithValue(FeatureMultiContainer, "disabled"))
+		Expect(features).To(HaveKeyWithValue(FeatureInitContainers, "enabled"))
+		Expect(features).To(HaveKeyWithValue(FeatureAffinity, "enabled"))
+		Expect(features).To(HaveKeyWithValue("kubernetes.podspec-fieldref", "enabled"))
+		Expect(defaultFeatures).NotTo(HaveKey(FeatureAffinity))
+	})
+
+	It("should reject invalid feature values and timeouts", func() {
+		Expect(ServingConfig{Features: map[string]string{FeatureAffinity: "on"}}.Validate()).
+			To(MatchError(ContainSubstring("must be enabled, disabled or allowed")))
+		Expect(ServingConfig{RevisionTimeout: 1500 * time.Millisecond}.Validate()).To(HaveOccurred())
+		Expect(ServingConfig{RevisionTimeout: -time.Second}.Validate()).To(HaveOccurred())
+		Expect(ServingConfig{RevisionTimeout: time.Minute, Features: map[string]string{FeatureAffinity: "allowed"}}.Validate()).
+			To(Succeed())
+	})
+})
@@ -0,0 +1,69 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// componentMetricsReader names the metrics RBAC in the install summary. It is not recorded in install-info.
+	componentMetricsReader = "metrics-reader"
+
+	// MetricsReaderClusterRoleName is the ClusterRole allowed to scrape the manager metrics endpoint.
+	MetricsReaderClusterRoleName = "fcp-metrics-reader"
+	// MetricsReaderClusterRoleBindingName binds the metrics scraper service account to MetricsReaderClusterRoleName.
+	MetricsReaderClusterRoleBindingName = "fcp-metrics-binding"
+)
+
+// EnsureMetricsReader creates or updates the fcp-metrics-reader ClusterRole. When scraper is set, the
+// service account is bound to it so it can read the metrics endpoint of the manager.
+func EnsureMetricsReader(ctx context.Context, k8sClient client.Client, scraper types.NamespacedName, ioStreams genericiooptions.IOStreams) error {
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: MetricsReaderClusterRoleName}}
+	_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, role, func() error {
+		role.Rules = metricsReaderRules()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure ClusterRole %s: %w", MetricsReaderClusterRoleName, err)
+	}
+	if scraper.Name == "" {
+		return nil
+	}
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: MetricsReaderClusterRoleBindingName}}
+	_, err = controllerutil.CreateOrUpdate(ctx, k8sClient, binding, func() error {
+		// The role of a binding is immutable, it is only set on creation.
+		if binding.CreationTimestamp.IsZero() {
+			binding.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     MetricsReaderClusterRoleName,
+			}
+		}
+		binding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      scraper.Name,
+			Namespace: scraper.Namespace,
+		}}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure ClusterRoleBinding %s: %w", MetricsReaderClusterRoleBindingName, err)
+	}
+	_, _ = fmt.Fprintln(ioStreams.Out, "Granted metrics access", "serviceAccount", scraper.String())
+	return nil
+}
+
+// metricsReaderRules allow reading the /metrics endpoint served by the manager.
+func metricsReaderRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{{
+		NonResourceURLs: []string{"/metrics"},
+		Verbs:           []string{"get"},
+	}}
+}
@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/scheme"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Metrics reader RBAC", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		ioStreams genericiooptions.IOStreams
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme.Get()).Build()
+		ioStreams, _, _, _ = genericiooptions.NewTestIOStreams()
+	})
+
+	It("should allow GET on /metrics and nothing else", func() {
+		Expect(EnsureMetricsReader(ctx, k8sClient, types.NamespacedName{}, ioStreams)).To(Succeed())
+
+		role := &rbacv1.ClusterRole{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: MetricsReaderClusterRoleName}, role)).To(Succeed())
+		Expect(role.Rules).To(ConsistOf(rbacv1.PolicyRule{
+			NonResourceURLs: []string{"/metrics"},
+			Verbs:           []string{"get"},
+		}))
+
+		By("not binding anyone without a scraper service account")
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: MetricsReaderClusterRoleBindingName}, &rbacv1.ClusterRoleBinding{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should bind the scraper service account and follow a change of account", func() {
+		scraper := types.NamespacedName{Namespace: "monitoring", Name: "prometheus"}
+		Expect(EnsureMetricsReader(ctx, k8sClient, scraper, ioStreams)).To(Succeed())
+
+		binding := &rbacv1.ClusterRoleBinding{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: MetricsReaderClusterRoleBindingName}, binding)).To(Succeed())
+		Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     MetricsReaderClusterRoleName,
+		}))
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: "monitoring",
+			Name:      "prometheus",
+		}))
+
+		scraper.Name = "vmagent"
+		Expect(EnsureMetricsReader(ctx, k8sClient, scraper, ioStreams)).To(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: MetricsReaderClusterRoleBindingName}, binding)).To(Succeed())
+		Expect(binding.Subjects).To(HaveLen(1))
+		Expect(binding.Subjects[0].Name).To(Equal("vmagent"))
+	})
+})
@@ -0,0 +1,51 @@
+package resource
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	stepCertManager    = "Checking cert-manager"
+	stepKnative        = "Checking Knative Serving"
+	stepServingConfig  = "Applying Knative Serving config"
+	stepHelm           = "Ensuring Helm binary"
+	stepMetricsReader  = "Ensuring metrics reader RBAC"
+	stepRecordVersions = "Recording installed component versions"
+)
+
+// progress prints the top level install steps as "[current/total] step" so long installs show
+// how far along they are. The steps of each component keep printing their own details below.
+type progress struct {
+	out     io.Writer
+	steps   []string
+	current int
+}
+
+func newProgress(out io.Writer, steps ...string) *progress {
+	return &progress{out: out, steps: steps}
+}
+
+// next prints the next step.
+func (p *progress) next() {
+	p.current++
+	_, _ = fmt.Fprintf(p.out, "[%d/%d] %s...\n", p.current, len(p.steps), p.steps[p.current-1])
+}
+
+// installSteps returns the steps of a fresh install, ending with recording the installed versions.
+func installSteps() []string {
+	return []string{stepCertManager, stepKnative, stepHelm, stepMetricsReader, stepRecordVersions}
+}
+
+// upgradeSteps returns one step per component to upgrade, the Knative Serving config when it is
+// applied without a Knative upgrade, followed by the Helm binary check and the metrics reader RBAC.
+func upgradeSteps(components []string, servingConfig bool) []string {
+	steps := make([]string, 0, len(components)+3)
+	for _, component := range components {
+		steps = append(steps, "Upgrading "+component)
+	}
+	if servingConfig {
+		steps = append(steps, stepServingConfig)
+	}
+	return append(steps, stepHelm, stepMetricsReader)
+}
@@ -0,0 +1,44 @@
+package resource
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Install progress", func() {
+	It("should number the steps of a fresh install", func() {
+		out := &bytes.Buffer{}
+		steps := newProgress(out, installSteps()...)
+		for range 5 {
+			steps.next()
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(Equal([]string{
+			"[1/5] Checking cert-manager...",
+			"[2/5] Checking Knative Serving...",
+			"[3/5] Ensuring Helm binary...",
+			"[4/5] Ensuring metrics reader RBAC...",
+			"[5/5] Recording installed component versions...",
+		}))
+	})
+
+	It("should count one step per upgraded component plus the Helm binary and metrics RBAC", func() {
+		out := &bytes.Buffer{}
+		components := []string{ComponentCertManager, ComponentKnative}
+		steps := newProgress(out, upgradeSteps(components, false)...)
+		for range len(components) + 2 {
+			steps.next()
+		}
+		Expect(out.String()).To(Equal("[1/4] Upgrading cert-manager...\n" +
+			"[2/4] Upgrading knative...\n" +
+			"[3/4] Ensuring Helm binary...\n" +
+			"[4/4] Ensuring metrics reader RBAC...\n"))
+	})
+
+	It("should add a step for a serving config applied without a Knative upgrade", func() {
+		Expect(upgradeSteps(nil, true)).To(Equal([]string{stepServingConfig, stepHelm, stepMetricsReader}))
+	})
+})
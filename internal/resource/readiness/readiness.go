@@ -0,0 +1,37 @@
+// Package readiness checks whether the deployments of the components installed by fcp are ready.
+package readiness
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeploymentReady checks if a specific deployment is ready (available). A missing deployment
+// returns the NotFound error.
+func DeploymentReady(ctx context.Context, k8sClient client.Client, nn types.NamespacedName) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := k8sClient.Get(ctx, nn, deployment); err != nil {
+		return false, err // Return the error (including NotFound)
+	}
+
+	// Check if the number of ready replicas equals the desired number
+	// and if the observed generation is the latest.
+	if deployment.Spec.Replicas != nil &&
+		deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == *deployment.Spec.Replicas &&
+		deployment.Status.Replicas == *deployment.Spec.Replicas &&
+		deployment.Status.AvailableReplicas == *deployment.Spec.Replicas {
+		// Check deployment conditions
+		for _, cond := range deployment.Status.Conditions {
+			if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil // Not ready yet
+}
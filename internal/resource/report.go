@@ -0,0 +1,162 @@
+package resource
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	utilexec "k8s.io/utils/exec"
+)
+
+const (
+	// componentHelm names the Helm binary in the install summary. It is not recorded in install-info.
+	componentHelm = "helm"
+	// componentServingConfig names the Knative Serving config applied without a Knative upgrade in
+	// the upgrade summary. It is not recorded in install-info.
+	componentServingConfig = "knative-serving-config"
+)
+
+// PartialInstallExitCode is the exit code of an install or upgrade where some components succeeded
+// and others failed or were skipped, telling it apart from a run where nothing could be applied.
+const PartialInstallExitCode = 2
+
+// ComponentStatus is the outcome of installing or upgrading one component.
+type ComponentStatus string
+
+const (
+	ComponentSucceeded ComponentStatus = "succeeded"
+	ComponentFailed    ComponentStatus = "failed"
+	// ComponentSkipped marks a component that was not attempted because a component it depends on
+	// did not succeed in the same run.
+	ComponentSkipped ComponentStatus = "skipped"
+)
+
+// ComponentResult records the outcome of one component.
+type ComponentResult struct {
+	Component string
+	Status    ComponentStatus
+	Err       error
+}
+
+// InstallReport aggregates the per-component results of an install or upgrade.
+type InstallReport struct {
+	Results []ComponentResult
+}
+
+// components returns the names of the components with the given status, in run order.
+func (r *InstallReport) components(status ComponentStatus) []string {
+	var names []string
+	for _, result := range r.Results {
+		if result.Status == status {
+			names = append(names, result.Component)
+		}
+	}
+	return names
+}
+
+// Print writes one line per component with its status and, for failed or skipped ones, the reason.
+func (r *InstallReport) Print(out io.Writer) error {
+	w := printers.GetNewTabWriter(out)
+	_, _ = fmt.Fprintln(w, "COMPONENT\tSTATUS\tDETAILS")
+	for _, result := range r.Results {
+		details := ""
+		if result.Err != nil {
+			details = result.Err.Error()
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", result.Component, result.Status, details)
+	}
+	return w.Flush()
+}
+
+// Err returns nil when every component succeeded. Otherwise it returns a *PartialInstallError
+// listing the components that failed or were skipped.
+func (r *InstallReport) Err() error {
+	failed, skipped := r.components(ComponentFailed), r.components(ComponentSkipped)
+	if len(failed) == 0 && len(skipped) == 0 {
+		return nil
+	}
+	return &PartialInstallError{
+		Succeeded: r.components(ComponentSucceeded),
+		Failed:    failed,
+		Skipped:   skipped,
+	}
+}
+
+// PartialInstallError is returned when some components of an install or upgrade failed or were
+// skipped. The versions of the components that succeeded are recorded, so running fcp install
+// --upgrade afterwards only applies the remaining ones. It implements utilexec.ExitError so the
+// command exits with PartialInstallExitCode when at least one component succeeded.
+type PartialInstallError struct {
+	Succeeded []string
+	Failed    []string
+	Skipped   []string
+}
+
+var _ utilexec.ExitError = &PartialInstallError{}
+
+func (e *PartialInstallError) Error() string {
+	msg := fmt.Sprintf("%d of %d components did not complete", len(e.Failed)+len(e.Skipped),
+		len(e.Succeeded)+len(e.Failed)+len(e.Skipped))
+	if len(e.Failed) > 0 {
+		msg += fmt.Sprintf(", failed: %s", strings.Join(e.Failed, ", "))
+	}
+	if len(e.Skipped) > 0 {
+		msg += fmt.Sprintf(", skipped: %s", strings.Join(e.Skipped, ", "))
+	}
+	return msg + "; fix the errors above and run fcp install --upgrade to resume"
+}
+
+func (e *PartialInstallError) String() string {
+	return e.Error()
+}
+
+func (e *PartialInstallError) Exited() bool {
+	return true
+}
+
+// ExitStatus is PartialInstallExitCode when some component succeeded and 1 when none did.
+func (e *PartialInstallError) ExitStatus() int {
+	if len(e.Succeeded) == 0 {
+		return 1
+	}
+	return PartialInstallExitCode
+}
+
+// component is one step of an install or upgrade.
+type component struct {
+	name string
+	// dependsOn lists the components that must not have failed or been skipped in the same run.
+	dependsOn []string
+	run       func() error
+}
+
+// runComponents runs the components in order, advancing steps before each one. A failing component
+// does not stop the run; only the components depending on it are skipped.
+func runComponents(steps *progress, ioStreams genericiooptions.IOStreams, components ...component) *InstallReport {
+	report := &InstallReport{}
+	var incomplete []string
+	for _, c := range components {
+		steps.next()
+		if i := slices.IndexFunc(c.dependsOn, func(dep string) bool { return slices.Contains(incomplete, dep) }); i >= 0 {
+			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Skipping component", "component", c.name, "because", c.dependsOn[i], "did not complete")
+			report.Results = append(report.Results, ComponentResult{
+				Component: c.name,
+				Status:    ComponentSkipped,
+				Err:       fmt.Errorf("%s did not complete", c.dependsOn[i]),
+			})
+			incomplete = append(incomplete, c.name)
+			continue
+		}
+		if err := c.run(); err != nil {
+			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error installing component", "component", c.name, "error", err)
+			report.Results = append(report.Results, ComponentResult{Component: c.name, Status: ComponentFailed, Err: err})
+			incomplete = append(incomplete, c.name)
+			continue
+		}
+		report.Results = append(report.Results, ComponentResult{Component: c.name, Status: ComponentSucceeded})
+	}
+	return report
+}
@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var _ = Describe("Install report", func() {
+	var (
+		ioStreams genericiooptions.IOStreams
+		out       *bytes.Buffer
+		ran       []string
+	)
+
+	BeforeEach(func() {
+		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
+		ran = nil
+	})
+
+	step := func(name string, err error, dependsOn ...string) component {
+		return component{name: name, dependsOn: dependsOn, run: func() error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+
+	run := func(components ...component) *InstallReport {
+		steps := make([]string, len(components))
+		for i, c := range components {
+			steps[i] = c.name
+		}
+		return runComponents(newProgress(&bytes.Buffer{}, steps...), ioStreams, components...)
+	}
+
+	It("should keep going after a failure and skip the dependent components", func() {
+		report := run(
+			step(ComponentCertManager, errors.New("webhook not ready")),
+			step(ComponentKnative, nil, ComponentCertManager),
+			step(componentHelm, nil),
+		)
+
+		Expect(ran).To(Equal([]string{ComponentCertManager, componentHelm}))
+		Expect(report.components(ComponentSucceeded)).To(Equal([]string{componentHelm}))
+		Expect(report.components(ComponentFailed)).To(Equal([]string{ComponentCertManager}))
+		Expect(report.components(ComponentSkipped)).To(Equal([]string{ComponentKnative}))
+
+		var partial *PartialInstallError
+		Expect(errors.As(report.Err(), &partial)).To(BeTrue())
+		Expect(partial.ExitStatus()).To(Equal(PartialInstallExitCode))
+		Expect(partial.Error()).To(Equal("2 of 3 components did not complete, failed: cert-manager, " +
+			"skipped: knative; fix the errors above and run fcp install --upgrade to resume"))
+	})
+
+	It("should print the status of every component", func() {
+		report := run(
+			step(ComponentCertManager, nil),
+			step(ComponentKnative, errors.New("operator timed out"), ComponentCertManager),
+		)
+
+		Expect(report.Print(out)).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`(?m)^cert-manager\s+succeeded\s*$`))
+		Expect(out.String()).To(MatchRegexp(`(?m)^knative\s+failed\s+operator timed out$`))
+	})
+
+	It("should only record the versions of the components that succeeded", func() {
+		report := run(step(ComponentCertManager, nil), step(ComponentKnative, errors.New("boom")), step(componentHelm, nil))
+
+		Expect(succeededVersions(report)).To(Equal(map[string]string{
+			ComponentCertManager: TargetVersions()[ComponentCertManager],
+		}))
+	})
+
+	It("should exit with the default code when nothing succeeded", func() {
+		report := run(step(ComponentCertManager, errors.New("boom")), step(ComponentKnative, nil, ComponentCertManager))
+
+		var partial *PartialInstallError
+		Expect(errors.As(report.Err(), &partial)).To(BeTrue())
+		Expect(partial.ExitStatus()).To(Equal(1))
+	})
+
+	It("should return no error when every component succeeded", func() {
+		Expect(run(step(ComponentCertManager, nil), step(componentHelm, nil)).Err()).To(Succeed())
+	})
+})
@@ -3,50 +3,185 @@ package resource
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"go.funccloud.dev/fcp/internal/resource/certmanager"
 	"go.funccloud.dev/fcp/internal/resource/helm"
 	"go.funccloud.dev/fcp/internal/resource/kind"
 	"go.funccloud.dev/fcp/internal/resource/knative"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func CheckOrInstallVersion(ctx context.Context, domain, pluginDir string, k8sClient client.Client, ioStreams genericiooptions.IOStreams) error {
-	onKind, err := kind.IsKindCluster(ctx, k8sClient)
+// CheckOrInstallVersion installs the platform components and records their versions. A platform
+// with recorded versions is refused with ErrAlreadyInstalled, since it is only changed through Upgrade.
+func CheckOrInstallVersion(ctx context.Context, domain, pluginDir string, onKind *bool, servingConfig knative.ServingConfig, metricsScraper types.NamespacedName, k8sClient client.Client, ioStreams genericiooptions.IOStreams) error {
+	installed, err := GetInstalledVersions(ctx, k8sClient)
 	if err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error checking for kindnet daemonset", "error", err)
-		// Decide if we should proceed or return; for now, assume not Kind if error occurs
-		onKind = false
-	}
-	if onKind {
-		_, _ = fmt.Fprintln(ioStreams.Out, "Detected Kind cluster via kindnet daemonset. Recommended for dev environment.")
-		if domain == "" {
-			_, _ = fmt.Fprintln(ioStreams.Out, "Setting domain to 127.0.0.1.sslip.io")
-			domain = "127.0.0.1.sslip.io"
-		}
-	} else {
-		_, _ = fmt.Fprintln(ioStreams.Out, "Did not detect Kind cluster (kindnet daemonset not found or error occurred).")
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error reading install info", "error", err)
+		return err
+	}
+	if installed != nil {
+		return fmt.Errorf("%w, run with --upgrade to apply the versions and config of this release", ErrAlreadyInstalled)
 	}
 
-	// Check if cert-manager is installed
-	err = certmanager.CheckOrInstallVersion(ctx, k8sClient, ioStreams)
-	if err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error checking or installing cert-manager", "error", err)
+	isKind, domain := detectKind(ctx, domain, onKind, k8sClient, ioStreams)
+	steps := newProgress(ioStreams.Out, installSteps()...)
+	report := runComponents(steps, ioStreams,
+		component{
+			name: ComponentCertManager,
+			run: func() error {
+				return certmanager.CheckOrInstallVersion(ctx, k8sClient, ioStreams)
+			},
+		},
+		component{
+			// The Knative ingress certificates are issued by cert-manager.
+			name:      ComponentKnative,
+			dependsOn: []string{ComponentCertManager},
+			run: func() error {
+				_, err := knative.CheckOrInstallVersion(ctx, domain, servingConfig, k8sClient, ioStreams, isKind)
+				return err
+			},
+		},
+		component{
+			name: componentHelm,
+			run: func() error {
+				return helm.EnsureHelmBinary(ioStreams, pluginDir)
+			},
+		},
+		component{
+			name: componentMetricsReader,
+			run: func() error {
+				return EnsureMetricsReader(ctx, k8sClient, metricsScraper, ioStreams)
+			},
+		},
+	)
+
+	// Components that did not complete are left out so a later --upgrade applies them.
+	steps.next()
+	if err := RecordInstalledVersions(ctx, k8sClient, succeededVersions(report)); err != nil {
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error recording install info", "error", err)
 		return err
 	}
+	return printReport(ioStreams, "Install summary:", report)
+}
+
+// Upgrade compares the versions recorded by a previous install with the ones bundled in this
+// release and re-applies only the components that changed. Downgrades are refused unless force is set.
+func Upgrade(ctx context.Context, domain, pluginDir string, force bool, onKind *bool, servingConfig knative.ServingConfig, metricsScraper types.NamespacedName, k8sClient client.Client, ioStreams genericiooptions.IOStreams) error {
+	isKind, domain := detectKind(ctx, domain, onKind, k8sClient, ioStreams)
 
-	// Check if Knative is installed, passing the onKind flag
-	_, err = knative.CheckOrInstallVersion(ctx, domain, k8sClient, ioStreams, onKind) // Pass onKind here
+	installed, err := GetInstalledVersions(ctx, k8sClient)
 	if err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error checking or installing Knative", "error", err)
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error reading install info", "error", err)
 		return err
 	}
-
-	err = helm.EnsureHelmBinary(ioStreams, pluginDir)
+	target := TargetVersions()
+	changed, err := PlanUpgrade(installed, target, force)
 	if err != nil {
-		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error ensuring Helm binary", "error", err)
+		_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error planning upgrade", "error", err)
+		return err
+	}
+	// The serving config reaches the KnativeServing CR through a Knative upgrade, so it is applied on
+	// its own when Knative is already at the target version.
+	applyServingConfig := !servingConfig.IsZero() && !slices.Contains(changed, ComponentKnative)
+	if len(changed) == 0 && !applyServingConfig {
+		_, _ = fmt.Fprintln(ioStreams.Out, "All components are already at the target version, nothing to upgrade.")
+		return nil
+	}
+
+	steps := newProgress(ioStreams.Out, upgradeSteps(changed, applyServingConfig)...)
+	components := make([]component, 0, len(changed)+3)
+	for _, name := range changed {
+		c := component{name: name}
+		switch name {
+		case ComponentCertManager:
+			c.run = func() error { return certmanager.InstallCertManager(ctx, k8sClient, ioStreams) }
+		case ComponentKnative:
+			c.dependsOn = []string{ComponentCertManager}
+			c.run = func() error { return knative.Upgrade(ctx, domain, servingConfig, k8sClient, ioStreams, isKind) }
+		}
+		upgrade := c.run
+		c.run = func() error {
+			_, _ = fmt.Fprintln(ioStreams.Out, "Upgrading component", "component", name,
+				"from", installed[name], "to", target[name])
+			if err := upgrade(); err != nil {
+				return err
+			}
+			return RecordInstalledVersions(ctx, k8sClient, map[string]string{name: target[name]})
+		}
+		components = append(components, c)
+	}
+	if applyServingConfig {
+		components = append(components, component{
+			name:      componentServingConfig,
+			dependsOn: []string{ComponentCertManager},
+			run: func() error {
+				return knative.ApplyServingConfig(ctx, domain, servingConfig, k8sClient, ioStreams, isKind)
+			},
+		})
+	}
+	components = append(components, component{
+		name: componentHelm,
+		run: func() error {
+			return helm.EnsureHelmBinary(ioStreams, pluginDir)
+		},
+	}, component{
+		name: componentMetricsReader,
+		run: func() error {
+			return EnsureMetricsReader(ctx, k8sClient, metricsScraper, ioStreams)
+		},
+	})
+	return printReport(ioStreams, "Upgrade summary:", runComponents(steps, ioStreams, components...))
+}
+
+// succeededVersions returns the target versions of the recorded components that succeeded.
+func succeededVersions(report *InstallReport) map[string]string {
+	target := TargetVersions()
+	versions := make(map[string]string)
+	for _, name := range report.components(ComponentSucceeded) {
+		if v, ok := target[name]; ok {
+			versions[name] = v
+		}
+	}
+	return versions
+}
+
+// printReport prints the per-component summary and returns the aggregated error of the run.
+func printReport(ioStreams genericiooptions.IOStreams, title string, report *InstallReport) error {
+	_, _ = fmt.Fprintln(ioStreams.Out, title)
+	if err := report.Print(ioStreams.Out); err != nil {
 		return err
 	}
-	return nil
+	return report.Err()
+}
+
+// detectKind reports whether the cluster is a Kind cluster and returns the domain to use,
+// defaulting it to sslip.io on Kind when none was given. A non-nil onKind overrides the detection.
+// Only Kind clusters get the Knative default-domain manifest and the NodePort ingress.
+func detectKind(ctx context.Context, domain string, onKind *bool, k8sClient client.Client, ioStreams genericiooptions.IOStreams) (bool, string) {
+	var isKind bool
+	if onKind != nil {
+		isKind = *onKind
+		_, _ = fmt.Fprintln(ioStreams.Out, "Skipping Kind cluster detection, --kind is set to", isKind)
+	} else {
+		var err error
+		isKind, err = kind.IsKindCluster(ctx, k8sClient)
+		if err != nil {
+			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error checking for kindnet daemonset", "error", err)
+			// Decide if we should proceed or return; for now, assume not Kind if error occurs
+			isKind = false
+		}
+		if isKind {
+			_, _ = fmt.Fprintln(ioStreams.Out, "Detected Kind cluster via kindnet daemonset. Recommended for dev environment.")
+		} else {
+			_, _ = fmt.Fprintln(ioStreams.Out, "Did not detect Kind cluster (kindnet daemonset not found or error occurred).")
+		}
+	}
+	if isKind && domain == "" {
+		_, _ = fmt.Fprintln(ioStreams.Out, "Setting domain to 127.0.0.1.sslip.io")
+		domain = "127.0.0.1.sslip.io"
+	}
+	return isKind, domain
 }
@@ -0,0 +1,14 @@
+package resource
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestResource(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Resource Suite")
+}
@@ -0,0 +1,61 @@
+package resource
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.funccloud.dev/fcp/internal/scheme"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Kind detection", func() {
+	var ioStreams genericiooptions.IOStreams
+
+	BeforeEach(func() {
+		ioStreams, _, _, _ = genericiooptions.NewTestIOStreams()
+	})
+
+	newClient := func(kindnet bool) client.Client {
+		builder := fake.NewClientBuilder().WithScheme(scheme.Get())
+		if kindnet {
+			builder = builder.WithObjects(&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "kindnet", Namespace: "kube-system"},
+			})
+		}
+		return builder.Build()
+	}
+
+	detect := func(kindnet bool, onKind *bool, domain string) (bool, string) {
+		return detectKind(context.Background(), domain, onKind, newClient(kindnet), ioStreams)
+	}
+
+	It("should detect Kind from the kindnet DaemonSet when not overridden", func() {
+		isKind, domain := detect(true, nil, "")
+		Expect(isKind).To(BeTrue())
+		Expect(domain).To(Equal("127.0.0.1.sslip.io"))
+
+		isKind, domain = detect(false, nil, "apps.example.com")
+		Expect(isKind).To(BeFalse())
+		Expect(domain).To(Equal("apps.example.com"))
+	})
+
+	It("should honor an explicit override either way", func() {
+		isKind, _ := detect(false, ptr.To(true), "apps.example.com")
+		Expect(isKind).To(BeTrue())
+
+		isKind, domain := detect(true, ptr.To(false), "")
+		Expect(isKind).To(BeFalse())
+		Expect(domain).To(BeEmpty())
+	})
+
+	It("should keep an explicit domain on Kind", func() {
+		_, domain := detect(true, nil, "apps.example.com")
+		Expect(domain).To(Equal("apps.example.com"))
+	})
+})
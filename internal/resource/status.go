@@ -0,0 +1,63 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"go.funccloud.dev/fcp/internal/resource/certmanager"
+	"go.funccloud.dev/fcp/internal/resource/knative"
+	"go.funccloud.dev/fcp/internal/resource/readiness"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeploymentHealth is the readiness of one deployment of an installed component.
+type DeploymentHealth struct {
+	Component string
+	types.NamespacedName
+	Ready bool
+	// Reason explains why the deployment is not ready.
+	Reason string
+}
+
+// CheckHealth reports the readiness of the deployments of every component installed by fcp.
+// Missing deployments are reported as not ready rather than as an error.
+func CheckHealth(ctx context.Context, k8sClient client.Client) ([]DeploymentHealth, error) {
+	components := []struct {
+		name        string
+		deployments []types.NamespacedName
+	}{
+		{ComponentCertManager, certmanager.Deployments()},
+		{ComponentKnative, knative.Deployments()},
+	}
+	var health []DeploymentHealth
+	for _, component := range components {
+		for _, nn := range component.deployments {
+			h := DeploymentHealth{Component: component.name, NamespacedName: nn}
+			ready, err := readiness.DeploymentReady(ctx, k8sClient, nn)
+			switch {
+			case apierrors.IsNotFound(err):
+				h.Reason = "not found"
+			case err != nil:
+				return nil, fmt.Errorf("failed to check deployment %s: %w", nn, err)
+			case ready:
+				h.Ready = true
+			default:
+				h.Reason = "not available"
+			}
+			health = append(health, h)
+		}
+	}
+	return health, nil
+}
+
+// Healthy reports whether every deployment is ready.
+func Healthy(health []DeploymentHealth) bool {
+	for _, h := range health {
+		if !h.Ready {
+			return false
+		}
+	}
+	return true
+}
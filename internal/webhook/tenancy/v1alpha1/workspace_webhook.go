@@ -24,9 +24,11 @@ import (
 
 	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
 	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -94,7 +96,7 @@ func (v *WorkspaceCustomValidator) ValidateCreate(ctx context.Context, obj runti
 		return nil, fmt.Errorf("expected a Workspace object but got %T", obj)
 	}
 	workspacelog.Info("Validation for Workspace upon creation", "name", workspace.GetName())
-	errs := validateWorkspace(workspace)
+	errs := ValidateWorkspace(workspace)
 	if len(errs) > 0 {
 		return nil, apierrors.NewInvalid(
 			tenancyv1alpha1.GroupVersion.WithKind("Workspace").GroupKind(),
@@ -114,7 +116,7 @@ func (v *WorkspaceCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 	if !ok {
 		return nil, fmt.Errorf("expected a Workspace object for the oldObj but got %T", newObj)
 	}
-	errs := validateWorkspace(workspace)
+	errs := ValidateWorkspace(workspace)
 	if workspaceOld.Spec.Type != workspace.Spec.Type {
 		errs = append(errs, field.Invalid(field.NewPath("spec").Child("type"), workspace.Spec.Type, "workspaceType is immutable"))
 	}
@@ -161,7 +163,10 @@ func (v *WorkspaceCustomValidator) ValidateDelete(ctx context.Context, obj runti
 	return nil, nil
 }
 
-func validateWorkspace(workspace *tenancyv1alpha1.Workspace) field.ErrorList {
+// ValidateWorkspace runs the Workspace checks that do not need a cluster or the previous
+// version of the object (type, owners, domain template and defaults). It is shared by the
+// admission webhook and offline tooling such as `fcp validate`.
+func ValidateWorkspace(workspace *tenancyv1alpha1.Workspace) field.ErrorList {
 	var errs field.ErrorList
 	ownersPath := field.NewPath("spec").Child("owners")
 	typePath := field.NewPath("spec").Child("type")
@@ -193,5 +198,60 @@ func validateWorkspace(workspace *tenancyv1alpha1.Workspace) field.ErrorList {
 			}
 		}
 	}
+	errs = append(errs, validateApplicationDomainTemplate(workspace)...)
+	errs = append(errs, validateDefaultContainerResources(workspace.Spec.DefaultContainerResources)...)
+	for i, secret := range workspace.Spec.ImagePullSecrets {
+		path := field.NewPath("spec", "imagePullSecrets").Index(i).Child("name")
+		for _, msg := range validation.IsDNS1123Subdomain(secret.Name) {
+			errs = append(errs, field.Invalid(path, secret.Name, msg))
+		}
+	}
+	return errs
+}
+
+// validateDefaultContainerResources checks that the default quantities are not negative and that no
+// request exceeds its limit, which would make every defaulted Application invalid.
+func validateDefaultContainerResources(resources *corev1.ResourceRequirements) field.ErrorList {
+	var errs field.ErrorList
+	if resources == nil {
+		return errs
+	}
+	path := field.NewPath("spec", "defaultContainerResources")
+	for name, quantity := range resources.Limits {
+		if quantity.Sign() < 0 {
+			errs = append(errs, field.Invalid(path.Child("limits").Key(string(name)), quantity.String(), "must not be negative"))
+		}
+	}
+	for name, quantity := range resources.Requests {
+		if quantity.Sign() < 0 {
+			errs = append(errs, field.Invalid(path.Child("requests").Key(string(name)), quantity.String(), "must not be negative"))
+		}
+		if limit, ok := resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			errs = append(errs, field.Invalid(path.Child("requests").Key(string(name)), quantity.String(),
+				fmt.Sprintf("must be less than or equal to the %s limit %s", name, limit.String())))
+		}
+	}
+	return errs
+}
+
+// validateApplicationDomainTemplate checks that the template renders a valid domain that differs
+// per Application, so that no two Applications of the workspace get the same default domain.
+func validateApplicationDomainTemplate(workspace *tenancyv1alpha1.Workspace) field.ErrorList {
+	tmpl := workspace.Spec.ApplicationDomainTemplate
+	if tmpl == "" {
+		return nil
+	}
+	path := field.NewPath("spec").Child("applicationDomainTemplate")
+	first, err := workspace.ApplicationDomain("app")
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, tmpl, err.Error())}
+	}
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(first) {
+		errs = append(errs, field.Invalid(path, tmpl, fmt.Sprintf("renders %q: %s", first, msg)))
+	}
+	if second, _ := workspace.ApplicationDomain("other"); second == first {
+		errs = append(errs, field.Invalid(path, tmpl, "must reference {{.Name}} so every application gets its own domain"))
+	}
 	return errs
 }
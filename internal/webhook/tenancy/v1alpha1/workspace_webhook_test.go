@@ -25,6 +25,7 @@ import (
 	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -175,6 +176,65 @@ var _ = Describe("Workspace Webhook", func() {
 			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
 		})
 
+		It("Should validate the application domain template", func() {
+			obj.Spec.Type = tenancyv1alpha1.WorkspaceTypePersonal
+			obj.Name = userName
+			obj.Spec.Owners = []corev1.ObjectReference{{
+				Kind: "User",
+				Name: userName,
+			}}
+			obj.Spec.ApplicationDomainTemplate = "{{.Name}}.{{.Workspace}}.apps.example.com"
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+
+			By("rejecting a template that does not parse")
+			obj.Spec.ApplicationDomainTemplate = "{{.Name}.apps.example.com"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("applicationDomainTemplate")))
+
+			By("rejecting an unknown key")
+			obj.Spec.ApplicationDomainTemplate = "{{.Team}}.apps.example.com"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			By("rejecting a template rendering an invalid host name")
+			obj.Spec.ApplicationDomainTemplate = "{{.Name}}_apps.example.com"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			By("rejecting a template that gives every application the same domain")
+			obj.Spec.ApplicationDomainTemplate = "{{.Workspace}}.apps.example.com"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("{{.Name}}")))
+		})
+
+		It("Should validate the image pull secret names", func() {
+			obj.Spec.Type = tenancyv1alpha1.WorkspaceTypePersonal
+			obj.Name = userName
+			obj.Spec.Owners = []corev1.ObjectReference{{
+				Kind: "User",
+				Name: userName,
+			}}
+			obj.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry"}}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+
+			obj.Spec.ImagePullSecrets = append(obj.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: "Bad_Name"})
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.imagePullSecrets[1].name")))
+		})
+
+		It("Should validate the default container resources", func() {
+			obj.Spec.Type = tenancyv1alpha1.WorkspaceTypePersonal
+			obj.Name = userName
+			obj.Spec.Owners = []corev1.ObjectReference{{
+				Kind: "User",
+				Name: userName,
+			}}
+			obj.Spec.DefaultContainerResources = &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+
+			obj.Spec.DefaultContainerResources.Requests[corev1.ResourceCPU] = resource.MustParse("1")
+			Expect(validator.ValidateCreate(ctx, obj)).Error().
+				To(MatchError(ContainSubstring("spec.defaultContainerResources.requests[cpu]")))
+		})
+
 		It("Should validate updates correctly", func() {
 			// Setup old object for comparison
 			oldObj = &tenancyv1alpha1.Workspace{
@@ -19,14 +19,27 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
 	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	knativeconfig "knative.dev/serving/pkg/apis/config"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -38,13 +51,45 @@ import (
 // log is for logging in this package.
 var applicationlog = logf.Log.WithName("application-resource")
 
+const (
+	// knativeServingNamespace is the namespace where the Knative Serving components run.
+	knativeServingNamespace = "knative-serving"
+	// hpaAutoscalerDeployment is the Knative HPA autoscaler, required by the cpu and memory metrics.
+	hpaAutoscalerDeployment = "autoscaler-hpa"
+	// revisionGenerationDigits is the room reserved in revision names for the Application generation.
+	revisionGenerationDigits = 6
+	// revisionVariantSuffixLength is the room reserved in revision names for the "-<hash>" suffix the
+	// controller appends when the template changes without a new generation, e.g. on relabeling.
+	revisionVariantSuffixLength = 1 + 8
+)
+
+// allowedFieldRefPaths are the downward API fields Knative accepts in container env
+// once the kubernetes.podspec-fieldref feature is enabled.
+var allowedFieldRefPaths = sets.New(
+	"metadata.name",
+	"metadata.namespace",
+	"metadata.uid",
+	"spec.nodeName",
+	"spec.serviceAccountName",
+	"status.hostIP",
+	"status.podIP",
+)
+
+// supportedUnsatisfiableActions are the accepted topology spread constraint whenUnsatisfiable values.
+var supportedUnsatisfiableActions = sets.New(corev1.DoNotSchedule, corev1.ScheduleAnyway)
+
 // SetupApplicationWebhookWithManager registers the webhook for Application in the manager.
-func SetupApplicationWebhookWithManager(mgr ctrl.Manager) error {
+// When ingressClasses are given, Applications may only select one of them.
+func SetupApplicationWebhookWithManager(mgr ctrl.Manager, ingressClasses ...string) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&workloadv1alpha1.Application{}).
 		WithValidator(&ApplicationCustomValidator{
+			Client:         mgr.GetClient(),
+			APIReader:      mgr.GetAPIReader(),
+			IngressClasses: sets.New(ingressClasses...),
+		}).
+		WithDefaulter(&ApplicationCustomDefaulter{
 			Client: mgr.GetClient(),
 		}).
-		WithDefaulter(&ApplicationCustomDefaulter{}).
 		Complete()
 }
 
@@ -56,7 +101,9 @@ func SetupApplicationWebhookWithManager(mgr ctrl.Manager) error {
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as it is used only for temporary operations and does not need to be deeply copied.
 type ApplicationCustomDefaulter struct {
-	// TODO(user): Add more fields as needed for defaulting
+	// Client reads the workspace for its default domain. Without it, as in offline tooling,
+	// no domain is defaulted.
+	client.Client
 }
 
 var _ webhook.CustomDefaulter = &ApplicationCustomDefaulter{}
@@ -83,18 +130,112 @@ func (d *ApplicationCustomDefaulter) Default(ctx context.Context, obj runtime.Ob
 	if application.Spec.Scale.Metric == "" {
 		application.Spec.Scale.Metric = workloadv1alpha1.MetricConcurrency
 	}
-	if application.Spec.Scale.Target == nil && application.Spec.Scale.TargetUtilizationPercentage == nil {
-		application.Spec.Scale.Target = ptr.To(workloadv1alpha1.DefaultTargetUtilization)
-	}
 	if application.Spec.Scale.MinReplicas == nil {
 		application.Spec.Scale.MinReplicas = ptr.To(workloadv1alpha1.DefaultMinReplicas)
 	}
 	if application.Spec.Scale.MaxReplicas == nil {
 		application.Spec.Scale.MaxReplicas = ptr.To(workloadv1alpha1.DefaultMaxReplicas)
 	}
+	if application.Spec.Metrics != nil && application.Spec.Metrics.Path == "" {
+		application.Spec.Metrics.Path = workloadv1alpha1.DefaultMetricsPath
+	}
+	if application.Annotations[workloadv1alpha1.DefaultLivenessProbeAnnotation] == "true" {
+		defaultLivenessProbe(application.Spec.Containers)
+	}
+	if d.Client != nil {
+		d.defaultFromWorkspace(ctx, application)
+	}
 	return nil
 }
 
+// defaultFromWorkspace merges the workspace ImagePullSecrets into the Application and sets the domain
+// rendered from the workspace ApplicationDomainTemplate and the workspace DefaultContainerResources.
+// Only new Applications get a default domain and resources, so removing them later sticks and
+// changing the workspace defaults does not roll existing Applications. Failures only skip the
+// defaults, since the Application is valid without them.
+func (d *ApplicationCustomDefaulter) defaultFromWorkspace(ctx context.Context, application *workloadv1alpha1.Application) {
+	workspace := &tenancyv1alpha1.Workspace{}
+	if err := d.Get(ctx, client.ObjectKey{Name: application.Namespace}, workspace); err != nil {
+		return
+	}
+	// Pods naming their own pull secrets ignore those of the ServiceAccount, so the workspace
+	// ones are added to the Application as well.
+	for _, secret := range workspace.Spec.ImagePullSecrets {
+		if !slices.Contains(application.Spec.ImagePullSecrets, secret) {
+			application.Spec.ImagePullSecrets = append(application.Spec.ImagePullSecrets, secret)
+		}
+	}
+	if !application.CreationTimestamp.IsZero() {
+		return
+	}
+	if len(application.Spec.Domains) == 0 {
+		defaultDomain(application, workspace)
+	}
+	defaultContainerResources(application.Spec.Containers, workspace.Spec.DefaultContainerResources)
+}
+
+// defaultContainerResources gives the containers declaring neither requests nor limits the
+// workspace default resources.
+func defaultContainerResources(containers []corev1.Container, defaults *corev1.ResourceRequirements) {
+	if defaults == nil {
+		return
+	}
+	for i := range containers {
+		resources := &containers[i].Resources
+		if len(resources.Requests) == 0 && len(resources.Limits) == 0 {
+			resources.Requests = defaults.Requests.DeepCopy()
+			resources.Limits = defaults.Limits.DeepCopy()
+		}
+	}
+}
+
+// defaultDomain sets the domain rendered from the workspace ApplicationDomainTemplate.
+func defaultDomain(application *workloadv1alpha1.Application, workspace *tenancyv1alpha1.Workspace) {
+	domain, err := workspace.ApplicationDomain(application.Name)
+	if err != nil {
+		applicationlog.Error(err, "unable to render the workspace application domain template",
+			"name", application.GetName(), "workspace", workspace.Name)
+		return
+	}
+	if domain != "" {
+		application.Spec.Domains = []string{domain}
+	}
+}
+
+// defaultLivenessProbe adds a TCP liveness probe on the serving port of the serving container,
+// the first one declaring ports, so that hung processes are restarted. An explicit probe is kept.
+func defaultLivenessProbe(containers []corev1.Container) {
+	for i := range containers {
+		container := &containers[i]
+		if len(container.Ports) == 0 {
+			continue
+		}
+		port, ok := servingPort(container.Ports)
+		if ok && container.LivenessProbe == nil {
+			container.LivenessProbe = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(port.ContainerPort)},
+				},
+			}
+		}
+		return
+	}
+}
+
+// servingPort returns the port Knative routes traffic to: the only port, or the one named after
+// a serving protocol when several are declared.
+func servingPort(ports []corev1.ContainerPort) (corev1.ContainerPort, bool) {
+	if len(ports) == 1 {
+		return ports[0], true
+	}
+	for _, port := range ports {
+		if workloadv1alpha1.ServingPortNames.Has(port.Name) {
+			return port, true
+		}
+	}
+	return corev1.ContainerPort{}, false
+}
+
 // +kubebuilder:webhook:path=/validate-workload-fcp-funccloud-com-v1alpha1-application,mutating=false,failurePolicy=fail,sideEffects=None,groups=workload.fcp.funccloud.com,resources=applications,verbs=create;update;delete,versions=v1alpha1,name=vapplication-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // ApplicationCustomValidator struct is responsible for validating the Application resource
@@ -104,22 +245,277 @@ func (d *ApplicationCustomDefaulter) Default(ctx context.Context, obj runtime.Ob
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type ApplicationCustomValidator struct {
 	client.Client
+	// APIReader reads the Knative Serving configuration straight from the API server, so the
+	// manager does not start an informer over every object of its kind. Defaults to the Client.
+	APIReader client.Reader
+	// IngressClasses are the ingress classes installed in the cluster. Empty accepts any class.
+	IngressClasses sets.Set[string]
 }
 
 var _ webhook.CustomValidator = &ApplicationCustomValidator{}
 
-func (v *ApplicationCustomValidator) validate(ctx context.Context, application *workloadv1alpha1.Application) field.ErrorList {
+// validate returns the validation errors for the Application together with admission warnings,
+// and the workspace of the Application when it could be fetched. A missing workspace blocks the
+// request, but any other error while looking it up (an unavailable API server, a missing tenancy
+// CRD) only produces a warning so that a flaky control plane does not wedge every Application
+// admission.
+//
+// oldApplication is nil on creation. On update, checks against other resources only run for the
+// fields that changed, see needsRecheck.
+func (v *ApplicationCustomValidator) validate(
+	ctx context.Context, oldApplication, application *workloadv1alpha1.Application,
+) (*tenancyv1alpha1.Workspace, field.ErrorList, admission.Warnings) {
 	var errs field.ErrorList
-	workspace := tenancyv1alpha1.Workspace{}
-	if err := v.Get(ctx, client.ObjectKey{Name: application.Namespace}, &workspace); err != nil {
-		if client.IgnoreNotFound(err) != nil {
+	var warnings admission.Warnings
+	workspace := &tenancyv1alpha1.Workspace{}
+	if err := v.Get(ctx, client.ObjectKey{Name: application.Namespace}, workspace); err != nil {
+		workspace = nil
+		if apierrors.IsNotFound(err) {
 			errs = append(errs, field.Invalid(field.NewPath("metadata").Child("namespace"),
-				application.Namespace, err.Error()))
-			return errs
+				application.Namespace, v.workspaceNotFoundDetail(ctx, application.Namespace)))
+		} else {
+			applicationlog.Error(err, "unable to verify workspace, admitting with a warning",
+				"name", application.GetName(), "workspace", application.Namespace)
+			warnings = append(warnings, fmt.Sprintf("workspace %q could not be verified: %v", application.Namespace, err))
+		}
+	}
+	if name := application.Spec.TrustBundleConfigMap; name != "" && needsRecheck(oldApplication, application, trustBundle) {
+		trustBundlePath := field.NewPath("spec").Child("trustBundleConfigMap")
+		cm := &corev1.ConfigMap{}
+		if err := v.Get(ctx, client.ObjectKey{Namespace: application.Namespace, Name: name}, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, field.NotFound(trustBundlePath, name))
+			} else {
+				errs = append(errs, field.InternalError(trustBundlePath, err))
+			}
+		} else if _, ok := cm.Data[workloadv1alpha1.TrustBundleKey]; !ok {
+			errs = append(errs, field.Invalid(trustBundlePath, name,
+				fmt.Sprintf("configmap must contain the %q key", workloadv1alpha1.TrustBundleKey)))
+		}
+	}
+	if needsRecheck(oldApplication, application, envFromSources) {
+		errs = append(errs, v.validateEnvFromSources(ctx, application)...)
+	}
+	featureErrs, featureWarnings := v.validateKnativeFeatures(ctx, oldApplication, application)
+	errs = append(errs, featureErrs...)
+	warnings = append(warnings, featureWarnings...)
+	if needsRecheck(oldApplication, application, terminationGracePeriod) {
+		errs = append(errs, v.validateRevisionTimeout(ctx, application)...)
+	}
+	if class := application.Spec.IngressClass; class != "" && v.IngressClasses.Len() > 0 && !v.IngressClasses.Has(class) {
+		errs = append(errs, field.NotSupported(field.NewPath("spec", "ingressClass"), class,
+			sets.List(v.IngressClasses)))
+	}
+	errs = append(errs, ValidateApplicationSpec(application)...)
+	return workspace, errs, warnings
+}
+
+// workspaceNotFoundDetail explains a missing workspace, pointing out namespaces that exist but are
+// not managed by a Workspace since applications can only be deployed into workspaces.
+func (v *ApplicationCustomValidator) workspaceNotFoundDetail(ctx context.Context, namespace string) string {
+	if err := v.Get(ctx, client.ObjectKey{Name: namespace}, &corev1.Namespace{}); err != nil {
+		return "workspace not found"
+	}
+	return fmt.Sprintf("workspace not found: namespace %q exists but is not a workspace, "+
+		"create a Workspace named %q to deploy applications into it", namespace, namespace)
+}
+
+// needsRecheck reports whether the fields returned by fields must be checked against the resources
+// they reference: always on creation, and on update only once they changed. An Application being
+// deleted is never rechecked, so a since-deleted reference cannot block the removal of its finalizer
+// or the labels the workspace controller patches onto it.
+func needsRecheck[T any](
+	oldApplication, application *workloadv1alpha1.Application, fields func(*workloadv1alpha1.Application) T,
+) bool {
+	if application.DeletionTimestamp != nil {
+		return false
+	}
+	return oldApplication == nil || !equality.Semantic.DeepEqual(fields(oldApplication), fields(application))
+}
+
+// trustBundle returns the trust bundle ConfigMap of the Application.
+func trustBundle(application *workloadv1alpha1.Application) string {
+	return application.Spec.TrustBundleConfigMap
+}
+
+// envFromSources returns the envFrom sources of every container of the Application.
+func envFromSources(application *workloadv1alpha1.Application) [][]corev1.EnvFromSource {
+	sources := make([][]corev1.EnvFromSource, 0, len(application.Spec.Containers))
+	for _, container := range application.Spec.Containers {
+		sources = append(sources, container.EnvFrom)
+	}
+	return sources
+}
+
+// validateEnvFromSources rejects envFrom ConfigMaps and Secrets that do not exist in the workspace
+// unless they are marked optional, since the revision pods would never start without them.
+// Only metadata is read, so Secret data never reaches the webhook.
+func (v *ApplicationCustomValidator) validateEnvFromSources(
+	ctx context.Context, application *workloadv1alpha1.Application,
+) field.ErrorList {
+	var errs field.ErrorList
+	checkSource := func(path *field.Path, kind, name string, optional *bool) {
+		if name == "" || ptr.Deref(optional, false) {
+			return
+		}
+		source := &metav1.PartialObjectMetadata{}
+		source.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+		err := v.Get(ctx, client.ObjectKey{Namespace: application.Namespace, Name: name}, source)
+		switch {
+		case apierrors.IsNotFound(err):
+			errs = append(errs, field.Invalid(path, name, fmt.Sprintf(
+				"%s not found in workspace %q, create it first or set optional to true", strings.ToLower(kind), application.Namespace)))
+		case err != nil:
+			errs = append(errs, field.InternalError(path, err))
+		}
+	}
+	for i, container := range application.Spec.Containers {
+		for j, envFrom := range container.EnvFrom {
+			path := field.NewPath("spec", "containers").Index(i).Child("envFrom").Index(j)
+			if ref := envFrom.ConfigMapRef; ref != nil {
+				checkSource(path.Child("configMapRef", "name"), "ConfigMap", ref.Name, ref.Optional)
+			}
+			if ref := envFrom.SecretRef; ref != nil {
+				checkSource(path.Child("secretRef", "name"), "Secret", ref.Name, ref.Optional)
+			}
+		}
+	}
+	return errs
+}
+
+// apiReader returns the reader for objects the webhook does not need cached.
+func (v *ApplicationCustomValidator) apiReader() client.Reader {
+	if v.APIReader != nil {
+		return v.APIReader
+	}
+	return v.Client
+}
+
+// knativeFeatureFlags maps the Knative features an Application can use to their flag.
+var knativeFeatureFlags = map[string]func(knativeconfig.Features) knativeconfig.Flag{
+	"multi-container": func(f knativeconfig.Features) knativeconfig.Flag { return f.MultiContainer },
+	knativeconfig.FeaturePodSpecFieldRef: func(f knativeconfig.Features) knativeconfig.Flag {
+		return f.PodSpecFieldRef
+	},
+	knativeconfig.FeaturePodSpecTopologySpreadConstraints: func(f knativeconfig.Features) knativeconfig.Flag {
+		return f.PodSpecTopologySpreadConstraints
+	},
+}
+
+// knativeFeatures returns the Knative features the Application uses with the fields using them.
+func knativeFeatures(application *workloadv1alpha1.Application) map[string][]*field.Path {
+	used := map[string][]*field.Path{}
+	if len(application.Spec.Containers) > 1 {
+		used["multi-container"] = append(used["multi-container"], field.NewPath("spec", "containers"))
+	}
+	if application.Spec.ExposePodMetadata {
+		used[knativeconfig.FeaturePodSpecFieldRef] = append(used[knativeconfig.FeaturePodSpecFieldRef],
+			field.NewPath("spec", "exposePodMetadata"))
+	}
+	if len(application.Spec.TopologySpreadConstraints) > 0 {
+		used[knativeconfig.FeaturePodSpecTopologySpreadConstraints] = append(
+			used[knativeconfig.FeaturePodSpecTopologySpreadConstraints], field.NewPath("spec", "topologySpreadConstraints"))
+	}
+	for i, container := range application.Spec.Containers {
+		for j, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.FieldRef != nil {
+				used[knativeconfig.FeaturePodSpecFieldRef] = append(used[knativeconfig.FeaturePodSpecFieldRef],
+					field.NewPath("spec", "containers").Index(i).Child("env").Index(j).Child("valueFrom", "fieldRef"))
+			}
+		}
+	}
+	return used
+}
+
+// validateKnativeFeatures rejects Applications using fields whose Knative feature flag is not enabled
+// in the config-features ConfigMap, since the Knative Service would be refused later on anyway.
+// On update only features the old Application did not use yet are checked, so disabling a flag
+// does not wedge the Applications already relying on it.
+func (v *ApplicationCustomValidator) validateKnativeFeatures(
+	ctx context.Context, oldApplication, application *workloadv1alpha1.Application,
+) (field.ErrorList, admission.Warnings) {
+	if application.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	used := knativeFeatures(application)
+	if oldApplication != nil {
+		for name := range knativeFeatures(oldApplication) {
+			delete(used, name)
+		}
+	}
+	if len(used) == 0 {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	err := v.apiReader().Get(ctx,
+		client.ObjectKey{Namespace: knativeServingNamespace, Name: knativeconfig.FeaturesConfigName}, cm)
+	if err != nil {
+		// Without the ConfigMap Knative Serving is not installed yet, so there is nothing to check against.
+		if !apierrors.IsNotFound(err) {
+			applicationlog.Error(err, "unable to read the Knative feature flags", "name", application.GetName())
+		}
+		return nil, nil
+	}
+	features, err := knativeconfig.NewFeaturesConfigFromMap(cm.Data)
+	if err != nil {
+		return nil, admission.Warnings{fmt.Sprintf("Knative feature flags could not be parsed: %v", err)}
+	}
+
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(used)) {
+		if knativeFeatureFlags[name](*features) == knativeconfig.Enabled {
+			continue
+		}
+		for _, path := range used[name] {
+			errs = append(errs, field.Forbidden(path, fmt.Sprintf(
+				"requires the Knative feature %q, set it to %q in configmap %s/%s",
+				name, "enabled", knativeServingNamespace, knativeconfig.FeaturesConfigName)))
+		}
+	}
+	return errs, nil
+}
+
+// terminationGracePeriod returns the termination grace period of the Application.
+func terminationGracePeriod(application *workloadv1alpha1.Application) *int64 {
+	return application.Spec.TerminationGracePeriodSeconds
+}
+
+// validateRevisionTimeout rejects a termination grace period above the max-revision-timeout-seconds
+// of the config-defaults ConfigMap, since Knative would refuse the resulting revision timeout.
+func (v *ApplicationCustomValidator) validateRevisionTimeout(
+	ctx context.Context, application *workloadv1alpha1.Application,
+) field.ErrorList {
+	grace := application.Spec.TerminationGracePeriodSeconds
+	if grace == nil {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	err := v.apiReader().Get(ctx,
+		client.ObjectKey{Namespace: knativeServingNamespace, Name: knativeconfig.DefaultsConfigName}, cm)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			applicationlog.Error(err, "unable to read the Knative defaults", "name", application.GetName())
 		}
-		errs = append(errs, field.Invalid(field.NewPath("metadata").Child("namespace"),
-			application.Namespace, "workspace not found"))
+		return nil
 	}
+	defaults, err := knativeconfig.NewDefaultsConfigFromMap(cm.Data)
+	if err != nil {
+		applicationlog.Error(err, "unable to parse the Knative defaults", "name", application.GetName())
+		return nil
+	}
+	if *grace > defaults.MaxRevisionTimeoutSeconds {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "terminationGracePeriodSeconds"), *grace,
+			fmt.Sprintf("must not exceed the Knative max-revision-timeout-seconds of %d set in configmap %s/%s",
+				defaults.MaxRevisionTimeoutSeconds, knativeServingNamespace, knativeconfig.DefaultsConfigName))}
+	}
+	return nil
+}
+
+// ValidateApplicationSpec runs the Application checks that do not need a cluster
+// (containers, images, ports and scale bounds). It is shared by the admission webhook
+// and offline tooling such as `fcp validate`.
+func ValidateApplicationSpec(application *workloadv1alpha1.Application) field.ErrorList {
+	var errs field.ErrorList
 	if len(application.Spec.Containers) < 1 {
 		errs = append(errs, field.Required(field.NewPath("spec").Child("containers"),
 			"at least one container is required"))
@@ -132,9 +528,116 @@ func (v *ApplicationCustomValidator) validate(ctx context.Context, application *
 		errs = append(errs, field.Required(field.NewPath("spec").Child("scale").Child("maxReplicas"),
 			"maxReplicas is required"))
 	}
-	if *application.Spec.Scale.MinReplicas > *application.Spec.Scale.MaxReplicas {
+	if application.Spec.Scale.MinReplicas != nil && application.Spec.Scale.MaxReplicas != nil &&
+		*application.Spec.Scale.MinReplicas > *application.Spec.Scale.MaxReplicas {
 		errs = append(errs, field.Invalid(field.NewPath("spec", "scale", "minReplicas"), application.Spec.Scale.MinReplicas, "minReplicas must be less than or equal to maxReplicas"))
 	}
+	if initialScale := application.Spec.Scale.InitialScale; initialScale != nil {
+		path := field.NewPath("spec", "scale", "initialScale")
+		if *initialScale < 0 {
+			errs = append(errs, field.Invalid(path, *initialScale, "initialScale must not be negative"))
+		} else if maxReplicas := application.Spec.Scale.MaxReplicas; maxReplicas != nil && *initialScale > *maxReplicas {
+			errs = append(errs, field.Invalid(path, *initialScale, "initialScale must be less than or equal to maxReplicas"))
+		}
+	}
+
+	// Knative rejects negative rollout durations and only accepts a second precision.
+	if rollout := application.Spec.RolloutDuration; rollout != nil &&
+		(rollout.Duration < 0 || rollout.Duration%time.Second != 0) {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "rolloutDuration"), rollout.Duration.String(),
+			"must be a non-negative whole number of seconds"))
+	}
+	if grace := application.Spec.TerminationGracePeriodSeconds; grace != nil && *grace < 1 {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "terminationGracePeriodSeconds"), *grace,
+			"must be greater than zero"))
+	}
+
+	if application.Spec.TLSRedirect != nil && *application.Spec.TLSRedirect &&
+		application.Spec.EnableTLS != nil && !*application.Spec.EnableTLS {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "tlsRedirect"), true,
+			"tlsRedirect requires enableTLS to be true"))
+	}
+
+	if metrics := application.Spec.Metrics; metrics != nil {
+		metricsPath := field.NewPath("spec", "metrics")
+		if metrics.Port < 1 || metrics.Port > 65535 {
+			errs = append(errs, field.Invalid(metricsPath.Child("port"), metrics.Port, "port must be between 1 and 65535"))
+		}
+		if metrics.Path != "" && !strings.HasPrefix(metrics.Path, "/") {
+			errs = append(errs, field.Invalid(metricsPath.Child("path"), metrics.Path, "path must start with /"))
+		}
+	}
+
+	// Domains become DomainMapping names, which map a whole host to the service. Knative has no
+	// path based routing, so a path (or scheme, or port) cannot be honoured and is rejected.
+	for i, domain := range application.Spec.Domains {
+		domainPath := field.NewPath("spec", "domains").Index(i)
+		if strings.ContainsAny(domain, "/:") {
+			errs = append(errs, field.Invalid(domainPath, domain,
+				"must be a bare host name; paths, schemes and ports are not supported by Knative domain mappings"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(domain) {
+			errs = append(errs, field.Invalid(domainPath, domain, msg))
+		}
+	}
+
+	// The hostname is joined with the platform domain by the controller, so it must be a single label.
+	if hostname := application.Spec.Hostname; hostname != "" {
+		for _, msg := range validation.IsDNS1123Label(hostname) {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "hostname"), hostname, msg))
+		}
+	}
+
+	if class := application.Spec.IngressClass; class != "" {
+		for _, msg := range validation.IsQualifiedName(class) {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "ingressClass"), class, msg))
+		}
+	}
+
+	if prefix := application.Spec.RevisionNamePrefix; prefix != "" {
+		prefixPath := field.NewPath("spec", "revisionNamePrefix")
+		for _, msg := range validation.IsDNS1123Label(prefix) {
+			errs = append(errs, field.Invalid(prefixPath, prefix, msg))
+		}
+		// Leave room for the generation and the variant suffix appended by the controller.
+		reserved := len(application.Name) + 1 + revisionGenerationDigits + revisionVariantSuffixLength
+		if len(prefix)+reserved > validation.DNS1123LabelMaxLength {
+			errs = append(errs, field.TooLong(prefixPath, prefix, validation.DNS1123LabelMaxLength-reserved))
+		}
+	}
+
+	if budget := application.Spec.DisruptionBudget; budget != nil {
+		budgetPath := field.NewPath("spec", "disruptionBudget")
+		switch {
+		case budget.MinAvailable == nil && budget.MaxUnavailable == nil:
+			errs = append(errs, field.Required(budgetPath, "one of minAvailable or maxUnavailable is required"))
+		case budget.MinAvailable != nil && budget.MaxUnavailable != nil:
+			errs = append(errs, field.Forbidden(budgetPath, "minAvailable and maxUnavailable are mutually exclusive"))
+		}
+		errs = append(errs, validateIntOrPercent(budget.MinAvailable, budgetPath.Child("minAvailable"))...)
+		errs = append(errs, validateIntOrPercent(budget.MaxUnavailable, budgetPath.Child("maxUnavailable"))...)
+	}
+
+	for i, constraint := range application.Spec.TopologySpreadConstraints {
+		constraintPath := field.NewPath("spec", "topologySpreadConstraints").Index(i)
+		if constraint.MaxSkew < 1 {
+			errs = append(errs, field.Invalid(constraintPath.Child("maxSkew"), constraint.MaxSkew, "must be greater than zero"))
+		}
+		if constraint.TopologyKey == "" {
+			errs = append(errs, field.Required(constraintPath.Child("topologyKey"), "topologyKey is required"))
+		} else {
+			for _, msg := range validation.IsQualifiedName(constraint.TopologyKey) {
+				errs = append(errs, field.Invalid(constraintPath.Child("topologyKey"), constraint.TopologyKey, msg))
+			}
+		}
+		if !supportedUnsatisfiableActions.Has(constraint.WhenUnsatisfiable) {
+			errs = append(errs, field.NotSupported(constraintPath.Child("whenUnsatisfiable"),
+				constraint.WhenUnsatisfiable, sets.List(supportedUnsatisfiableActions)))
+		}
+		errs = append(errs, metav1validation.ValidateLabelSelector(constraint.LabelSelector,
+			metav1validation.LabelSelectorValidationOptions{}, constraintPath.Child("labelSelector"))...)
+	}
 
 	for i, container := range application.Spec.Containers {
 		if container.Image == "" {
@@ -144,6 +647,113 @@ func (v *ApplicationCustomValidator) validate(ctx context.Context, application *
 			errs = append(errs, field.Required(field.NewPath("spec").Child("containers").Child("ports"),
 				"ports is required"))
 		}
+		if container.Lifecycle != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "containers").Index(i).Child("lifecycle"),
+				"lifecycle hooks are not supported by Knative, use spec.terminationGracePeriodSeconds "+
+					"to let in-flight requests finish on shutdown"))
+		}
+		if len(container.Ports) > 1 {
+			errs = append(errs, validateServingPort(container.Ports,
+				field.NewPath("spec", "containers").Index(i).Child("ports"))...)
+		}
+		for j, env := range container.Env {
+			if env.ValueFrom == nil || env.ValueFrom.FieldRef == nil {
+				continue
+			}
+			if !allowedFieldRefPaths.Has(env.ValueFrom.FieldRef.FieldPath) {
+				errs = append(errs, field.NotSupported(
+					field.NewPath("spec", "containers").Index(i).Child("env").Index(j).Child("valueFrom", "fieldRef", "fieldPath"),
+					env.ValueFrom.FieldRef.FieldPath, sets.List(allowedFieldRefPaths)))
+			}
+		}
+	}
+	return errs
+}
+
+// validateServingPort checks that exactly one of several container ports is named after a
+// serving protocol, so the controller knows which one Knative routes traffic to and probes.
+func validateServingPort(ports []corev1.ContainerPort, path *field.Path) field.ErrorList {
+	var serving []string
+	for _, port := range ports {
+		if workloadv1alpha1.ServingPortNames.Has(port.Name) {
+			serving = append(serving, port.Name)
+		}
+	}
+	if len(serving) != 1 {
+		return field.ErrorList{field.Invalid(path, serving, fmt.Sprintf(
+			"exactly one port must be named %s when a container declares several ports",
+			strings.Join(sets.List(workloadv1alpha1.ServingPortNames), " or ")))}
+	}
+	return nil
+}
+
+// validateIntOrPercent checks that value is a non-negative integer or a percentage between 0% and 100%.
+func validateIntOrPercent(value *intstr.IntOrString, path *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	if value.Type == intstr.Int {
+		if value.IntVal < 0 {
+			return field.ErrorList{field.Invalid(path, value.IntVal, "must be greater than or equal to 0")}
+		}
+		return nil
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(value.StrVal, "%"))
+	if !strings.HasSuffix(value.StrVal, "%") || err != nil || percent < 0 || percent > 100 {
+		return field.ErrorList{field.Invalid(path, value.StrVal, "must be an integer or a percentage between 0% and 100%")}
+	}
+	return nil
+}
+
+// autoscalerWarnings warns when the Application asks for an HPA-class metric (cpu or memory)
+// but the Knative HPA autoscaler is not installed, since such an app would never scale. On update it
+// only warns when the metric changed.
+func (v *ApplicationCustomValidator) autoscalerWarnings(
+	ctx context.Context, oldApplication, application *workloadv1alpha1.Application,
+) admission.Warnings {
+	metric := application.Spec.Scale.Metric
+	if metric != workloadv1alpha1.MetricCPU && metric != workloadv1alpha1.MetricMemory {
+		return nil
+	}
+	if oldApplication != nil && oldApplication.Spec.Scale.Metric == metric {
+		return nil
+	}
+	hpa := &appsv1.Deployment{}
+	err := v.apiReader().Get(ctx, client.ObjectKey{Namespace: knativeServingNamespace, Name: hpaAutoscalerDeployment}, hpa)
+	if err == nil {
+		return nil
+	}
+	if apierrors.IsNotFound(err) {
+		return admission.Warnings{fmt.Sprintf(
+			"spec.scale.metric %q requires the Knative HPA autoscaler, but deployment %s/%s was not found; "+
+				"install the Knative HPA extension or use the %q or %q metric",
+			metric, knativeServingNamespace, hpaAutoscalerDeployment,
+			workloadv1alpha1.MetricConcurrency, workloadv1alpha1.MetricRPS)}
+	}
+	applicationlog.Error(err, "unable to check for the Knative HPA autoscaler", "name", application.GetName())
+	return nil
+}
+
+// validateWorkspaceNotSuspended rejects new Applications in a suspended workspace. Existing ones
+// may still be updated so the workspace controller can label them. A nil workspace could not be
+// fetched, which validate already reports.
+func validateWorkspaceNotSuspended(workspace *tenancyv1alpha1.Workspace) *field.Error {
+	if workspace != nil && workspace.Spec.Suspended {
+		return field.Forbidden(field.NewPath("metadata").Child("namespace"),
+			fmt.Sprintf("workspace %q is suspended, no new applications can be created", workspace.Name))
+	}
+	return nil
+}
+
+// validateApplicationName checks that the name can be used for the Knative Service, which requires a
+// DNS-1035 label. Knative hashes the revision names it generates for long Service names, and revision
+// names built from spec.revisionNamePrefix are bounded along with the prefix. Names are immutable,
+// so this only runs on creation.
+func validateApplicationName(name string) field.ErrorList {
+	var errs field.ErrorList
+	namePath := field.NewPath("metadata", "name")
+	for _, msg := range validation.IsDNS1035Label(name) {
+		errs = append(errs, field.Invalid(namePath, name, msg))
 	}
 	return errs
 }
@@ -158,32 +768,42 @@ func (v *ApplicationCustomValidator) ValidateCreate(
 	}
 	applicationlog.Info("Validation for Application upon creation", "name", application.GetName())
 
-	errs := v.validate(ctx, application)
+	workspace, errs, warnings := v.validate(ctx, nil, application)
+	errs = append(errs, validateApplicationName(application.Name)...)
+	warnings = append(warnings, v.autoscalerWarnings(ctx, nil, application)...)
+	if err := validateWorkspaceNotSuspended(workspace); err != nil {
+		errs = append(errs, err)
+	}
 	// check if workspace exists and namespaces are the same nam
 	if len(errs) > 0 {
-		return nil, apierrors.NewInvalid(
+		return warnings, apierrors.NewInvalid(
 			workloadv1alpha1.GroupVersion.WithKind("Application").GroupKind(),
 			application.GetName(), errs)
 	}
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Application.
 func (v *ApplicationCustomValidator) ValidateUpdate(
 	ctx context.Context, oldObj, newObj runtime.Object,
 ) (admission.Warnings, error) {
+	oldApplication, ok := oldObj.(*workloadv1alpha1.Application)
+	if !ok {
+		return nil, fmt.Errorf("expected a Application object for the oldObj but got %T", oldObj)
+	}
 	application, ok := newObj.(*workloadv1alpha1.Application)
 	if !ok {
 		return nil, fmt.Errorf("expected a Application object for the newObj but got %T", newObj)
 	}
 	applicationlog.Info("Validation for Application upon update", "name", application.GetName())
-	errs := v.validate(ctx, application)
+	_, errs, warnings := v.validate(ctx, oldApplication, application)
+	warnings = append(warnings, v.autoscalerWarnings(ctx, oldApplication, application)...)
 	if len(errs) > 0 {
-		return nil, apierrors.NewInvalid(
+		return warnings, apierrors.NewInvalid(
 			workloadv1alpha1.GroupVersion.WithKind("Application").GroupKind(),
 			application.GetName(), errs)
 	}
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Application.
@@ -18,16 +18,25 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	tenancyv1alpha1 "go.funccloud.dev/fcp/api/tenancy/v1alpha1"
 	workloadv1alpha1 "go.funccloud.dev/fcp/api/workload/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 var _ = Describe("Application Webhook", func() {
@@ -127,7 +136,7 @@ var _ = Describe("Application Webhook", func() {
 
 			By("checking that the default Scale values are set")
 			Expect(obj.Spec.Scale.Metric).To(Equal(workloadv1alpha1.MetricConcurrency))
-			Expect(obj.Spec.Scale.Target).To(Equal(ptr.To(workloadv1alpha1.DefaultTargetUtilization)))
+			Expect(obj.Spec.Scale.Target).To(BeNil())
 			Expect(obj.Spec.Scale.TargetUtilizationPercentage).To(BeNil())
 		})
 
@@ -153,6 +162,1235 @@ var _ = Describe("Application Webhook", func() {
 			Expect(obj.Spec.Scale.Target).To(Equal(&target))
 			Expect(obj.Spec.Scale.TargetUtilizationPercentage).To(BeNil()) // TargetUtilizationPercentage should NOT be defaulted
 		})
+
+		It("Should leave the Target to the profile when one is selected", func() {
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app-ginkgo-profile", Namespace: "test-ns-ginkgo-profile"},
+				Spec:       workloadv1alpha1.ApplicationSpec{Profile: workloadv1alpha1.ProfileBatch},
+			}
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec.Scale.Target).To(BeNil())
+			Expect(obj.Spec.Scale.Metric).To(Equal(workloadv1alpha1.MetricConcurrency))
+		})
+
+		It("Should add a TCP liveness probe on the serving port when opted in", func() {
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-app-ginkgo-probe",
+					Namespace:   "test-ns-ginkgo-probe",
+					Annotations: map[string]string{workloadv1alpha1.DefaultLivenessProbeAnnotation: "true"},
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{Image: "nginx:latest", Ports: []corev1.ContainerPort{
+							{Name: "admin", ContainerPort: 9091},
+							{Name: "http1", ContainerPort: 8080},
+						}},
+						{Image: "envoy:latest"},
+					},
+				},
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Containers[0].LivenessProbe).NotTo(BeNil())
+			Expect(obj.Spec.Containers[0].LivenessProbe.TCPSocket).To(Equal(&corev1.TCPSocketAction{Port: intstr.FromInt32(8080)}))
+			Expect(obj.Spec.Containers[1].LivenessProbe).To(BeNil())
+		})
+
+		It("Should keep explicit probes and add none without the annotation", func() {
+			explicit := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"},
+			}}
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-app-ginkgo-probe-explicit",
+					Namespace:   "test-ns-ginkgo-probe",
+					Annotations: map[string]string{workloadv1alpha1.DefaultLivenessProbeAnnotation: "true"},
+				},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image:         "nginx:latest",
+						Ports:         []corev1.ContainerPort{{ContainerPort: 80}},
+						LivenessProbe: explicit.DeepCopy(),
+					}},
+				},
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Containers[0].LivenessProbe).To(Equal(explicit))
+
+			By("leaving the probe unset without the annotation")
+			obj.Annotations = nil
+			obj.Spec.Containers[0].LivenessProbe = nil
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Containers[0].LivenessProbe).To(BeNil())
+		})
+
+		It("Should default the domain from the workspace template", func() {
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ns-ginkgo-domain"},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					ApplicationDomainTemplate: "{{.Name}}.{{.Workspace}}.apps.example.com",
+				},
+			}
+			defaulter = ApplicationCustomDefaulter{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+			}
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ws.Name},
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Domains).To(Equal([]string{"web.test-ns-ginkgo-domain.apps.example.com"}))
+
+			By("keeping explicit domains")
+			obj.Spec.Domains = []string{"www.example.com"}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Domains).To(Equal([]string{"www.example.com"}))
+
+			By("not defaulting an existing Application")
+			obj.Spec.Domains = nil
+			obj.CreationTimestamp = metav1.Now()
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Domains).To(BeEmpty())
+
+			By("not defaulting outside a workspace")
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "not-a-workspace"},
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Domains).To(BeEmpty())
+		})
+
+		It("Should merge the workspace image pull secrets into the Application", func() {
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ns-ginkgo-pull-secrets"},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+				},
+			}
+			defaulter = ApplicationCustomDefaulter{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+			}
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ws.Name},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "own"}, {Name: "mirror"}},
+				},
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{
+				{Name: "own"}, {Name: "mirror"}, {Name: "registry"},
+			}))
+
+			By("not duplicating them on update")
+			obj.CreationTimestamp = metav1.Now()
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ImagePullSecrets).To(HaveLen(3))
+		})
+
+		It("Should default the container resources from the workspace only when none are set", func() {
+			defaults := &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ns-ginkgo-resources"},
+				Spec:       tenancyv1alpha1.WorkspaceSpec{DefaultContainerResources: defaults},
+			}
+			defaulter = ApplicationCustomDefaulter{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+			}
+			own := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}
+			obj = &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ws.Name},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "app:latest"},
+						{Name: "sidecar", Image: "sidecar:latest", Resources: own},
+					},
+				},
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Containers[0].Resources).To(Equal(*defaults))
+			Expect(obj.Spec.Containers[1].Resources).To(Equal(own))
+
+			By("not defaulting an existing Application")
+			obj.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+			obj.CreationTimestamp = metav1.Now()
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Containers[0].Resources).To(BeZero())
+		})
+	})
+
+	Context("When validating an Application spec without a cluster", func() {
+		It("should report missing scale bounds instead of panicking", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-scale-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+				},
+			}
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("minReplicas is required"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("maxReplicas is required"))
+		})
+
+		It("should accept a defaulted Application", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaulted-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+		})
+
+		It("should reject domains with a path, scheme or port", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "domain-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Domains: []string{"app.example.com"},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			app.Spec.Domains = []string{"example.com/api", "https://example.com", "example.com:8443", "Bad_Host"}
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(4))
+			Expect(errs[0].Field).To(Equal("spec.domains[0]"))
+			Expect(errs[0].Detail).To(ContainSubstring("paths, schemes and ports are not supported"))
+			Expect(errs[3].Field).To(Equal("spec.domains[3]"))
+		})
+
+		It("should reject rollout durations Knative cannot honour", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollout-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					RolloutDuration: &metav1.Duration{},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			for _, d := range []time.Duration{-time.Minute, 1500 * time.Millisecond} {
+				app.Spec.RolloutDuration = &metav1.Duration{Duration: d}
+				errs := ValidateApplicationSpec(app)
+				Expect(errs).To(HaveLen(1), d.String())
+				Expect(errs[0].Field).To(Equal("spec.rolloutDuration"))
+			}
+		})
+
+		It("should reject a TLS redirect without TLS", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "redirect-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					TLSRedirect: ptr.To(false),
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			app.Spec.EnableTLS = ptr.To(false)
+			app.Spec.TLSRedirect = ptr.To(true)
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.tlsRedirect"))
+		})
+
+		It("should validate topology spread constraints", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "spread-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+					}},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			app.Spec.TopologySpreadConstraints[0] = corev1.TopologySpreadConstraint{
+				MaxSkew:           0,
+				WhenUnsatisfiable: "Sometimes",
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Near"}},
+				},
+			}
+			errs := ValidateApplicationSpec(app)
+			fields := make([]string, 0, len(errs))
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			Expect(fields).To(ConsistOf(
+				"spec.topologySpreadConstraints[0].maxSkew",
+				"spec.topologySpreadConstraints[0].topologyKey",
+				"spec.topologySpreadConstraints[0].whenUnsatisfiable",
+				"spec.topologySpreadConstraints[0].labelSelector.matchExpressions[0].operator",
+			))
+		})
+
+		It("should validate the disruption budget", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "pdb-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					DisruptionBudget: &workloadv1alpha1.DisruptionBudget{
+						MaxUnavailable: ptr.To(intstr.FromString("25%")),
+					},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			app.Spec.DisruptionBudget = &workloadv1alpha1.DisruptionBudget{}
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+
+			app.Spec.DisruptionBudget = &workloadv1alpha1.DisruptionBudget{
+				MinAvailable:   ptr.To(intstr.FromInt32(-1)),
+				MaxUnavailable: ptr.To(intstr.FromString("150%")),
+			}
+			errs = ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[1].Field).To(Equal("spec.disruptionBudget.minAvailable"))
+			Expect(errs[2].Field).To(Equal("spec.disruptionBudget.maxUnavailable"))
+		})
+
+		It("should reject an invalid revision name prefix", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "prefixed-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					RevisionNamePrefix: "v",
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			app.Spec.RevisionNamePrefix = "V_1"
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).NotTo(BeEmpty())
+			Expect(errs[0].Field).To(Equal("spec.revisionNamePrefix"))
+
+			app.Spec.RevisionNamePrefix = strings.Repeat("v", 60)
+			errs = ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeTooLong))
+
+			By("leaving room for the generation and the variant suffix")
+			// "prefixed-app-" + prefix + 6 generation digits + "-" + 8 hash characters
+			app.Spec.RevisionNamePrefix = strings.Repeat("v", 35)
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+			app.Spec.RevisionNamePrefix = strings.Repeat("v", 36)
+			errs = ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeTooLong))
+		})
+
+		It("should reject a hostname that is not a single DNS label", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "hostname-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Hostname: "api",
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			for _, hostname := range []string{"api.example.com", "API", "-api"} {
+				app.Spec.Hostname = hostname
+				errs := ValidateApplicationSpec(app)
+				Expect(errs).NotTo(BeEmpty(), hostname)
+				Expect(errs[0].Field).To(Equal("spec.hostname"))
+			}
+		})
+
+		It("should reject an initial scale that is negative or above maxReplicas", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "initial-scale-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MaxReplicas:  ptr.To[int32](5),
+						InitialScale: ptr.To[int32](3),
+					},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			for _, initialScale := range []int32{-1, 6} {
+				app.Spec.Scale.InitialScale = ptr.To(initialScale)
+				errs := ValidateApplicationSpec(app)
+				Expect(errs).To(HaveLen(1), "initialScale %d", initialScale)
+				Expect(errs[0].Field).To(Equal("spec.scale.initialScale"))
+			}
+		})
+
+		It("should reject env fieldRefs Knative does not allow", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "fieldref-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+						Env: []corev1.EnvVar{
+							{Name: "NODE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+							{Name: "LABELS", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"}}},
+						},
+					}},
+					ExposePodMetadata: true,
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.containers[0].env[1].valueFrom.fieldRef.fieldPath"))
+		})
+
+		It("should require exactly one serving port when a container declares several", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "ports-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{
+							{Name: "admin", ContainerPort: 9091},
+							{Name: "h2c", ContainerPort: 8080},
+						},
+					}},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			By("rejecting ports none of which is named after a serving protocol")
+			app.Spec.Containers[0].Ports[1].Name = "web"
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.containers[0].ports"))
+			Expect(errs[0].Detail).To(ContainSubstring("h2c or http1"))
+
+			By("rejecting ambiguous serving ports")
+			app.Spec.Containers[0].Ports[0].Name = "http1"
+			app.Spec.Containers[0].Ports[1].Name = "h2c"
+			errs = ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.containers[0].ports"))
+		})
+
+		It("should default the metrics path and reject an invalid one", func() {
+			app := &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "metrics-app"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Metrics: &workloadv1alpha1.Metrics{Port: 9090},
+				},
+			}
+			Expect(defaulter.Default(ctx, app)).To(Succeed())
+			Expect(app.Spec.Metrics.Path).To(Equal(workloadv1alpha1.DefaultMetricsPath))
+			Expect(ValidateApplicationSpec(app)).To(BeEmpty())
+
+			app.Spec.Metrics.Path = "metrics"
+			app.Spec.Metrics.Port = 0
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.metrics.port"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.metrics.path"))
+		})
+	})
+
+	Context("When validating an Application with an HPA metric", func() {
+		const wsName = "hpa-ws"
+
+		newApp := func(metric workloadv1alpha1.Metric) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "hpa-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](3),
+						Metric:      metric,
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			validator = ApplicationCustomValidator{Client: k8sClient}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: wsName}},
+				},
+			}
+			err := k8sClient.Create(ctx, ws)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should warn when the Knative HPA autoscaler is not installed", func() {
+			warnings, err := validator.ValidateCreate(ctx, newApp(workloadv1alpha1.MetricCPU))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("requires the Knative HPA autoscaler"))
+		})
+
+		It("should only warn on update when the metric changes", func() {
+			warnings, err := validator.ValidateUpdate(ctx, newApp(workloadv1alpha1.MetricCPU), newApp(workloadv1alpha1.MetricCPU))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			warnings, err = validator.ValidateUpdate(ctx, newApp(workloadv1alpha1.MetricCPU), newApp(workloadv1alpha1.MetricMemory))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("requires the Knative HPA autoscaler"))
+		})
+
+		It("should not warn for KPA metrics", func() {
+			warnings, err := validator.ValidateCreate(ctx, newApp(workloadv1alpha1.MetricConcurrency))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should not warn once the Knative HPA autoscaler is installed", func() {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: knativeServingNamespace}}
+			err := k8sClient.Create(ctx, ns)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+			labels := map[string]string{"app": hpaAutoscalerDeployment}
+			hpa := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: hpaAutoscalerDeployment, Namespace: knativeServingNamespace},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "autoscaler-hpa", Image: "autoscaler-hpa:latest"}},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpa)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, hpa)).To(Succeed())
+			})
+
+			warnings, err := validator.ValidateCreate(ctx, newApp(workloadv1alpha1.MetricMemory))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("When validating an Application with a trust bundle", func() {
+		const wsName = "trust-ws"
+
+		newApp := func(configMap string) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "trust-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					TrustBundleConfigMap: configMap,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			validator = ApplicationCustomValidator{Client: k8sClient}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			err := k8sClient.Create(ctx, ns)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: wsName}},
+				},
+			}
+			err = k8sClient.Create(ctx, ws)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny a trust bundle ConfigMap that does not exist", func() {
+			_, err := validator.ValidateCreate(ctx, newApp("missing-ca"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.trustBundleConfigMap: Not found"))
+		})
+
+		It("should deny a trust bundle ConfigMap without the CA key", func() {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-key-ca", Namespace: wsName},
+				Data:       map[string]string{"other": "data"},
+			}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+			_, err := validator.ValidateCreate(ctx, newApp(cm.Name))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(workloadv1alpha1.TrustBundleKey))
+		})
+
+		It("should allow an existing trust bundle ConfigMap", func() {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "corp-ca", Namespace: wsName},
+				Data:       map[string]string{workloadv1alpha1.TrustBundleKey: "-----BEGIN CERTIFICATE-----"},
+			}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+			_, err := validator.ValidateCreate(ctx, newApp(cm.Name))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow updates that keep a since-deleted trust bundle", func() {
+			app := newApp("deleted-ca")
+			app.Labels = map[string]string{tenancyv1alpha1.WorkspaceSuspendedLabel: "true"}
+			_, err := validator.ValidateUpdate(ctx, newApp("deleted-ca"), app)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny updates that switch to a missing trust bundle", func() {
+			_, err := validator.ValidateUpdate(ctx, newApp(""), newApp("missing-ca"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.trustBundleConfigMap: Not found"))
+		})
+
+		It("should allow an Application being deleted to drop its finalizer", func() {
+			oldApp := newApp("deleted-ca")
+			oldApp.Finalizers = []string{workloadv1alpha1.ApplicationFinalizer}
+			app := newApp("deleted-ca")
+			app.DeletionTimestamp = ptr.To(metav1.Now())
+			_, err := validator.ValidateUpdate(ctx, oldApp, app)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When validating an Application with envFrom sources", func() {
+		const wsName = "envfrom-ws"
+
+		newApp := func(envFrom ...corev1.EnvFromSource) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "envfrom-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image:   "nginx:latest",
+						Ports:   []corev1.ContainerPort{{ContainerPort: 80}},
+						EnvFrom: envFrom,
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+				},
+			}
+		}
+
+		configMapRef := func(name string, optional *bool) corev1.EnvFromSource {
+			return corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional,
+			}}
+		}
+
+		secretRef := func(name string, optional *bool) corev1.EnvFromSource {
+			return corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional,
+			}}
+		}
+
+		BeforeEach(func() {
+			validator = ApplicationCustomValidator{Client: k8sClient}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			err := k8sClient.Create(ctx, ns)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: wsName}},
+				},
+			}
+			err = k8sClient.Create(ctx, ws)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny missing ConfigMap and Secret sources that are not optional", func() {
+			_, err := validator.ValidateCreate(ctx, newApp(configMapRef("missing-config", nil), secretRef("missing-secret", ptr.To(false))))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.containers[0].envFrom[0].configMapRef.name"))
+			Expect(err.Error()).To(ContainSubstring("spec.containers[0].envFrom[1].secretRef.name"))
+			Expect(err.Error()).To(ContainSubstring("set optional to true"))
+		})
+
+		It("should allow missing sources marked optional", func() {
+			_, err := validator.ValidateCreate(ctx, newApp(configMapRef("missing-config", ptr.To(true)), secretRef("missing-secret", ptr.To(true))))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow existing sources", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: wsName}}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "app-secret", Namespace: wsName}}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			_, err := validator.ValidateCreate(ctx, newApp(configMapRef(cm.Name, nil), secretRef(secret.Name, nil)))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow updates that keep a since-deleted source", func() {
+			oldApp := newApp(configMapRef("deleted-config", nil))
+			app := newApp(configMapRef("deleted-config", nil))
+			app.Labels = map[string]string{tenancyv1alpha1.WorkspaceSuspendedLabel: "true"}
+			_, err := validator.ValidateUpdate(ctx, oldApp, app)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny updates that add a missing source", func() {
+			_, err := validator.ValidateUpdate(ctx, newApp(), newApp(secretRef("missing-secret", nil)))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.containers[0].envFrom[0].secretRef.name"))
+		})
+
+		It("should allow an Application being deleted to drop its finalizer", func() {
+			oldApp := newApp(configMapRef("deleted-config", nil))
+			oldApp.Finalizers = []string{workloadv1alpha1.ApplicationFinalizer}
+			app := newApp(secretRef("deleted-secret", nil))
+			app.DeletionTimestamp = ptr.To(metav1.Now())
+			_, err := validator.ValidateUpdate(ctx, oldApp, app)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When validating an Application in a suspended workspace", func() {
+		const wsName = "suspended-ws"
+
+		newApp := func() *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "suspended-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			validator = ApplicationCustomValidator{Client: k8sClient}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:      tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners:    []corev1.ObjectReference{{Kind: "User", Name: wsName}},
+					Suspended: true,
+				},
+			}
+			err := k8sClient.Create(ctx, ws)
+			if apierrors.IsAlreadyExists(err) {
+				err = nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny new applications", func() {
+			_, err := validator.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`workspace "suspended-ws" is suspended`))
+		})
+
+		It("should still allow updates to existing applications", func() {
+			app := newApp()
+			app.Labels = map[string]string{tenancyv1alpha1.WorkspaceSuspendedLabel: "true"}
+			_, err := validator.ValidateUpdate(ctx, newApp(), app)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow new applications in an active workspace", func() {
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: wsName},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:   tenancyv1alpha1.WorkspaceTypePersonal,
+					Owners: []corev1.ObjectReference{{Kind: "User", Name: wsName}},
+				},
+			}
+			validator = ApplicationCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+			}
+			_, err := validator.ValidateCreate(ctx, newApp())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When the Application name is close to the DNS label limit", func() {
+		const wsName = "names-ws"
+
+		newApp := func(name string) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			ws := &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			validator = ApplicationCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+			}
+		})
+
+		It("should accept the longest DNS label, leaving revision names to Knative", func() {
+			_, err := validator.ValidateCreate(ctx, newApp("a"+strings.Repeat("b", 62)))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a name one character too long", func() {
+			_, err := validator.ValidateCreate(ctx, newApp("a"+strings.Repeat("b", 63)))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("metadata.name"))
+			Expect(err.Error()).To(ContainSubstring("no more than 63 characters"))
+		})
+
+		It("should bound long names through the revision name prefix", func() {
+			app := newApp("a" + strings.Repeat("b", 62))
+			app.Spec.RevisionNamePrefix = "v"
+			_, err := validator.ValidateCreate(ctx, app)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.revisionNamePrefix: Too long"))
+		})
+
+		It("should reject a name that is not a DNS-1035 label", func() {
+			_, err := validator.ValidateCreate(ctx, newApp("1st-app"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("metadata.name"))
+		})
+
+		It("should not block updates of existing applications", func() {
+			name := "a" + strings.Repeat("b", 60)
+			_, err := validator.ValidateUpdate(ctx, newApp(name), newApp(name))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When the Application relies on Knative feature flags", func() {
+		const wsName = "features-ws"
+
+		newApp := func() *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "features-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{
+						{Image: "nginx:latest", Ports: []corev1.ContainerPort{{ContainerPort: 80}}},
+						{Image: "envoy:latest"},
+					},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					ExposePodMetadata: true,
+				},
+			}
+		}
+
+		newValidator := func(features map[string]string) ApplicationCustomValidator {
+			ws := &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "config-features", Namespace: "knative-serving"},
+				Data:       features,
+			}
+			return ApplicationCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws, cm).Build(),
+			}
+		}
+
+		It("should reject fields whose feature flag is disabled, naming the flag", func() {
+			v := newValidator(map[string]string{
+				"multi-container":             "disabled",
+				"kubernetes.podspec-fieldref": "disabled",
+			})
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`requires the Knative feature "multi-container"`))
+			Expect(err.Error()).To(ContainSubstring(`requires the Knative feature "kubernetes.podspec-fieldref"`))
+		})
+
+		It("should reject fields relying on a feature that is disabled by default", func() {
+			v := newValidator(map[string]string{})
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).NotTo(ContainSubstring(`"multi-container"`))
+			Expect(err.Error()).To(ContainSubstring(`"kubernetes.podspec-fieldref"`))
+		})
+
+		It("should allow the fields once the feature flags are enabled", func() {
+			v := newValidator(map[string]string{
+				"multi-container":             "enabled",
+				"kubernetes.podspec-fieldref": "enabled",
+			})
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the topology spread constraints feature", func() {
+			app := newApp()
+			app.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}}
+			features := map[string]string{
+				"multi-container":             "enabled",
+				"kubernetes.podspec-fieldref": "enabled",
+			}
+			v := newValidator(features)
+			_, err := v.ValidateCreate(ctx, app)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`requires the Knative feature "kubernetes.podspec-topologyspreadconstraints"`))
+
+			features["kubernetes.podspec-topologyspreadconstraints"] = "enabled"
+			v = newValidator(features)
+			_, err = v.ValidateCreate(ctx, app)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only reject features newly used by an update", func() {
+			v := newValidator(map[string]string{
+				"multi-container":             "disabled",
+				"kubernetes.podspec-fieldref": "disabled",
+			})
+			oldApp := newApp()
+			oldApp.Spec.Containers = oldApp.Spec.Containers[:1]
+			app := newApp()
+			app.Spec.Containers = app.Spec.Containers[:1]
+			_, err := v.ValidateUpdate(ctx, oldApp, app)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = v.ValidateUpdate(ctx, oldApp, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`requires the Knative feature "multi-container"`))
+			Expect(err.Error()).NotTo(ContainSubstring(`"kubernetes.podspec-fieldref"`))
+		})
+
+		It("should read the feature flags through the API reader", func() {
+			ws := &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "config-features", Namespace: "knative-serving"},
+				Data:       map[string]string{"multi-container": "disabled"},
+			}
+			v := ApplicationCustomValidator{
+				Client:    fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+				APIReader: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(cm).Build(),
+			}
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`requires the Knative feature "multi-container"`))
+		})
+	})
+
+	Context("When the Application sets a termination grace period", func() {
+		const wsName = "grace-ws"
+
+		newApp := func(grace int64) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "grace-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{Image: "nginx:latest", Ports: []corev1.ContainerPort{{ContainerPort: 80}}}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					TerminationGracePeriodSeconds: ptr.To(grace),
+				},
+			}
+		}
+
+		newValidator := func(defaults map[string]string) ApplicationCustomValidator {
+			ws := &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "config-defaults", Namespace: "knative-serving"},
+				Data:       defaults,
+			}
+			return ApplicationCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws, cm).Build(),
+			}
+		}
+
+		It("should reject a grace period above the Knative max revision timeout", func() {
+			v := newValidator(map[string]string{})
+			_, err := v.ValidateCreate(ctx, newApp(600))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = v.ValidateCreate(ctx, newApp(601))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("max-revision-timeout-seconds of 600"))
+
+			v = newValidator(map[string]string{"max-revision-timeout-seconds": "3600"})
+			_, err = v.ValidateCreate(ctx, newApp(1800))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only check the grace period on update when it changes", func() {
+			v := newValidator(map[string]string{})
+			app := newApp(1800)
+			app.Labels = map[string]string{tenancyv1alpha1.WorkspaceSuspendedLabel: "true"}
+			_, err := v.ValidateUpdate(ctx, newApp(1800), app)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = v.ValidateUpdate(ctx, newApp(30), newApp(1800))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("max-revision-timeout-seconds of 600"))
+		})
+
+		It("should reject a non-positive grace period and lifecycle hooks", func() {
+			app := newApp(0)
+			app.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+				PreStop: &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 5}},
+			}
+			errs := ValidateApplicationSpec(app)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.terminationGracePeriodSeconds"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.containers[0].lifecycle"))
+		})
+	})
+
+	Context("When the Application namespace is not a workspace", func() {
+		const wsName = "plain-ns"
+
+		newApp := func() *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "plain-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+				},
+			}
+		}
+
+		newValidator := func(objs ...client.Object) ApplicationCustomValidator {
+			return ApplicationCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(objs...).Build(),
+			}
+		}
+
+		It("should report a missing workspace when the namespace does not exist", func() {
+			v := newValidator()
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("workspace not found"))
+			Expect(err.Error()).NotTo(ContainSubstring("is not a workspace"))
+		})
+
+		It("should explain that an existing namespace is not a workspace", func() {
+			v := newValidator(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: wsName}})
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`namespace "plain-ns" exists but is not a workspace`))
+			Expect(err.Error()).To(ContainSubstring(`create a Workspace named "plain-ns"`))
+		})
+
+		It("should admit the Application once the workspace exists", func() {
+			v := newValidator(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: wsName}},
+				&tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: wsName}},
+			)
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When the Application selects an ingress class", func() {
+		const wsName = "ingress-ws"
+
+		newApp := func(class string) *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "ingress-app", Namespace: wsName},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+					IngressClass: class,
+				},
+			}
+		}
+
+		newValidator := func(classes ...string) ApplicationCustomValidator {
+			ws := &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: wsName}}
+			return ApplicationCustomValidator{
+				Client:         fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(ws).Build(),
+				IngressClasses: sets.New(classes...),
+			}
+		}
+
+		It("should accept any well-formed class when no classes are configured", func() {
+			v := newValidator()
+			_, err := v.ValidateCreate(ctx, newApp("istio.ingress.networking.knative.dev"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = v.ValidateCreate(ctx, newApp("not a class"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.ingressClass"))
+		})
+
+		It("should only accept the configured classes", func() {
+			v := newValidator("kourier.ingress.networking.knative.dev", "contour.ingress.networking.knative.dev")
+			_, err := v.ValidateCreate(ctx, newApp("kourier.ingress.networking.knative.dev"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = v.ValidateCreate(ctx, newApp(""))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = v.ValidateCreate(ctx, newApp("istio.ingress.networking.knative.dev"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`spec.ingressClass: Unsupported value: "istio.ingress.networking.knative.dev"`))
+			Expect(err.Error()).To(ContainSubstring("kourier.ingress.networking.knative.dev"))
+		})
+	})
+
+	Context("When the workspace lookup fails", func() {
+		newApp := func() *workloadv1alpha1.Application {
+			return &workloadv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "flaky-app", Namespace: "flaky-ws"},
+				Spec: workloadv1alpha1.ApplicationSpec{
+					Containers: []corev1.Container{{
+						Image: "nginx:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					}},
+					Scale: workloadv1alpha1.Scale{
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](1),
+					},
+				},
+			}
+		}
+
+		newValidator := func(getErr error) ApplicationCustomValidator {
+			return ApplicationCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(k8sClient.Scheme()).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey,
+							obj client.Object, opts ...client.GetOption) error {
+							if _, ok := obj.(*tenancyv1alpha1.Workspace); ok && getErr != nil {
+								return getErr
+							}
+							return c.Get(ctx, key, obj, opts...)
+						},
+					}).
+					Build(),
+			}
+		}
+
+		It("should deny the Application when the workspace does not exist", func() {
+			v := newValidator(nil)
+			_, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("workspace not found"))
+		})
+
+		It("should admit the Application with a warning on transient API errors", func() {
+			v := newValidator(apierrors.NewServerTimeout(
+				tenancyv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), "get", 1))
+			warnings, err := v.ValidateCreate(ctx, newApp())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring(`workspace "flaky-ws" could not be verified`))
+		})
 	})
 
 	Context("When creating or updating Application under Validating Webhook", func() {
@@ -0,0 +1,60 @@
+package yamlutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// OCIScheme prefixes manifest locations stored as OCI artifacts, e.g.
+// "oci://ghcr.io/example/manifests:v1.0.0".
+const OCIScheme = "oci://"
+
+// pullManifestFromOCI fetches a manifest stored as an OCI artifact. Every layer of the artifact
+// is read as a YAML document, the way `oras push` stores files, and the layers are joined in order.
+// Registry credentials come from the docker config, as used by `docker login`.
+func pullManifestFromOCI(ctx context.Context, location string) ([]byte, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(location, OCIScheme))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %s: %w", location, err)
+	}
+	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("error pulling %s: %w", location, err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("error reading layers of %s: %w", location, err)
+	}
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("no layers in %s", location)
+	}
+	var docs []string
+	for _, layer := range layers {
+		doc, err := readLayer(layer.Compressed)
+		if err != nil {
+			return nil, fmt.Errorf("error reading layer of %s: %w", location, err)
+		}
+		docs = append(docs, doc)
+	}
+	return []byte(strings.Join(docs, "\n---\n")), nil
+}
+
+// readLayer reads the raw blob of a layer; artifact layers hold the file as is.
+func readLayer(open func() (io.ReadCloser, error)) (string, error) {
+	rc, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
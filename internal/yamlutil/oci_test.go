@@ -0,0 +1,67 @@
+package yamlutil
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var _ = Describe("DownloadYAMLFromURL from an OCI registry", func() {
+	var (
+		server    *httptest.Server
+		repo      string
+		ioStreams = genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(registry.New())
+		DeferCleanup(server.Close)
+		repo = strings.TrimPrefix(server.URL, "http://") + "/manifests"
+	})
+
+	push := func(tag string, docs ...string) {
+		img := empty.Image
+		for _, doc := range docs {
+			var err error
+			img, err = mutate.AppendLayers(img, static.NewLayer([]byte(doc), types.MediaType("application/yaml")))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		ref, err := name.ParseReference(repo + ":" + tag)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(ref, img)).To(Succeed())
+	}
+
+	It("should join the layers of the artifact", func() {
+		push("v1", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: b\n")
+
+		manifest, err := DownloadYAMLFromURL(context.Background(), OCIScheme+repo+":v1", ioStreams)
+		Expect(err).NotTo(HaveOccurred())
+		objs, err := DecodeManifest(string(manifest))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(2))
+		Expect(objs[0].GetName()).To(Equal("a"))
+		Expect(objs[1].GetName()).To(Equal("b"))
+	})
+
+	It("should fail for a missing tag", func() {
+		_, err := DownloadYAMLFromURL(context.Background(), OCIScheme+repo+":missing", ioStreams)
+		Expect(err).To(MatchError(ContainSubstring("error pulling")))
+	})
+
+	It("should fail for an invalid reference", func() {
+		_, err := DownloadYAMLFromURL(context.Background(), OCIScheme+"Invalid Reference", ioStreams)
+		Expect(err).To(MatchError(ContainSubstring("invalid OCI reference")))
+	})
+})
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,7 +35,32 @@ func ApplyManifestFromURL(ctx context.Context, k8sClient client.Client, ioStream
 
 // ApplyManifestYAML applies a Kubernetes manifest provided as a YAML string.
 // It decodes the YAML and applies each object using Server-Side Apply.
+// Namespaces and CustomResourceDefinitions are applied before any other object,
+// so a manifest does not need to list them first.
 func ApplyManifestYAML(ctx context.Context, k8sClient client.Client, manifestYAML string, ioStreams genericiooptions.IOStreams) error {
+	objs, err := DecodeManifest(manifestYAML)
+	if err != nil {
+		return err
+	}
+	sortForApply(objs)
+
+	for _, obj := range objs {
+		_, _ = fmt.Fprintln(ioStreams.Out, "Applying object", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+		patch := client.Apply
+		opts := []client.PatchOption{client.ForceOwnership, client.FieldOwner("fcp-manager")}
+		err = k8sClient.Patch(ctx, obj, patch, opts...)
+		if err != nil {
+			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to apply object", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace(), "error", err)
+			return fmt.Errorf("failed to apply object %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// DecodeManifest decodes every non-empty document of a multi-document YAML manifest.
+func DecodeManifest(manifestYAML string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
 	decoder := yaml.NewYAMLToJSONDecoder(strings.NewReader(manifestYAML))
 	for {
 		obj := &unstructured.Unstructured{}
@@ -42,27 +69,45 @@ func ApplyManifestYAML(ctx context.Context, k8sClient client.Client, manifestYAM
 			if err == io.EOF {
 				break // End of YAML stream
 			}
-			return fmt.Errorf("failed to decode YAML object: %w", err)
+			return nil, fmt.Errorf("failed to decode YAML object: %w", err)
 		}
 
 		if obj.Object == nil {
 			continue // Skip empty objects
 		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
 
-		_, _ = fmt.Fprintln(ioStreams.Out, "Applying object", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
-
-		patch := client.Apply
-		opts := []client.PatchOption{client.ForceOwnership, client.FieldOwner("fcp-manager")}
-		err = k8sClient.Patch(ctx, obj, patch, opts...)
-		if err != nil {
-			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Failed to apply object", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace(), "error", err)
-			return fmt.Errorf("failed to apply object %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+// sortForApply moves Namespaces and then CustomResourceDefinitions to the front,
+// keeping the manifest order for everything else.
+func sortForApply(objs []*unstructured.Unstructured) {
+	priority := func(obj *unstructured.Unstructured) int {
+		switch obj.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Kind: "Namespace"}:
+			return 0
+		case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+			return 1
 		}
+		return 2
 	}
-	return nil
+	slices.SortStableFunc(objs, func(a, b *unstructured.Unstructured) int {
+		return priority(a) - priority(b)
+	})
 }
 
+// DownloadYAMLFromURL fetches a YAML manifest over HTTP, or from an OCI registry when the
+// URL starts with oci://.
 func DownloadYAMLFromURL(ctx context.Context, url string, ioStreams genericiooptions.IOStreams) ([]byte, error) {
+	if strings.HasPrefix(url, OCIScheme) {
+		manifestBytes, err := pullManifestFromOCI(ctx, url)
+		if err != nil {
+			_, _ = fmt.Fprintln(ioStreams.ErrOut, "Error pulling manifest", "url", url, "error", err)
+			return nil, err
+		}
+		return manifestBytes, nil
+	}
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second, // Set a timeout for the HTTP request
 	}
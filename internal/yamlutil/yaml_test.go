@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"slices"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap/zapcore"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,7 +38,19 @@ var _ = Describe("YAML Utils", func() {
 		scheme = runtime.NewScheme()
 		// Add necessary schemes if testing with specific K8s types
 		// e.g., corev1.AddToScheme(scheme)
-		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		// The fake client does not implement server-side apply, so emulate it with create-or-update.
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return cl.Patch(ctx, obj, patch, opts...)
+				}
+				err := cl.Create(ctx, obj)
+				if apierrors.IsAlreadyExists(err) {
+					return cl.Update(ctx, obj)
+				}
+				return err
+			},
+		}).Build()
 	})
 
 	Describe("ApplyManifestYAML", func() {
@@ -99,6 +113,48 @@ data:
 			})
 		})
 
+		Context("with a namespaced object listed before its Namespace", func() {
+			It("should apply the Namespace and CRDs first", func() {
+				manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm-ordered
+  namespace: ordered-ns
+data:
+  key: value
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ordered-ns
+`
+				var applied []string
+				orderedClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if ns := obj.GetNamespace(); ns != "" && !slices.Contains(applied, "Namespace/"+ns) {
+							return fmt.Errorf("namespaces %q not found", ns)
+						}
+						applied = append(applied, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+						return nil
+					},
+				}).Build()
+				ioStreams := genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+				err := ApplyManifestYAML(ctx, orderedClient, manifest, ioStreams)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(applied).To(Equal([]string{
+					"Namespace/ordered-ns",
+					"CustomResourceDefinition/widgets.example.com",
+					"ConfigMap/test-cm-ordered",
+				}))
+			})
+		})
+
 		Context("with an empty manifest", func() {
 			It("should return no error", func() {
 				manifest := ``
@@ -0,0 +1,14 @@
+package yamlutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestYAMLUtil(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "YAMLUtil Suite")
+}